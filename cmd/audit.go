@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"harvest-cli/pkg/audit"
+	"harvest-cli/pkg/config"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// AuditCmd returns the audit command
+func AuditCmd() *cobra.Command {
+	var from, to, format, output string
+	var allProfiles bool
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show the audit log of time entry changes",
+		Long: `Show every create, update and delete request sent to Harvest with its request body,
+response and status code, recorded in the audit log in the data directory.
+Example: h audit --from 2024-01-01 --to 2024-03-31 --format csv -o audit.csv
+
+By default, shows the requests of the current month for the configured account.
+Use --format flag to export the entries as json or csv, and -o flag to write them to a file.
+Use --all-profiles flag to include requests sent to other Harvest accounts.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// Default to the current month up to today
+			now := time.Now()
+			fromDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+			toDate := now
+
+			var err error
+			if from != "" {
+				fromDate, err = time.ParseInLocation("2006-01-02", from, time.Local)
+				if err != nil {
+					log.Fatalf("Invalid from date. Please use YYYY-MM-DD format: %v", err)
+				}
+			}
+			if to != "" {
+				toDate, err = time.ParseInLocation("2006-01-02", to, time.Local)
+				if err != nil {
+					log.Fatalf("Invalid to date. Please use YYYY-MM-DD format: %v", err)
+				}
+			}
+			if toDate.Before(fromDate) {
+				log.Fatalf("The to date must not be before the from date")
+			}
+
+			handleAudit(fromDate, toDate, format, output, allProfiles)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringVar(&from, "from", "", "Start date in YYYY-MM-DD format (default: first day of the current month)")
+	cmd.Flags().StringVar(&to, "to", "", "End date in YYYY-MM-DD format (default: today)")
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "Output format: table, json or csv")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the entries to a file instead of the terminal")
+	cmd.Flags().BoolVar(&allProfiles, "all-profiles", false, "Include requests sent to other Harvest accounts")
+
+	return cmd
+}
+
+// handleAudit prints or exports the audit log entries between two dates
+func handleAudit(from, to time.Time, format, output string, allProfiles bool) {
+	if format != "table" && format != "json" && format != "csv" {
+		log.Fatalf("Invalid format: %s, expected table, json or csv", format)
+	}
+
+	dataDir, err := appConfig.GetDataDir()
+	if err != nil {
+		log.Fatalf("Failed to get data directory: %v", err)
+	}
+	auditLog := audit.Open(dataDir)
+
+	allEntries, err := auditLog.Entries(from, to)
+	if err != nil {
+		log.Fatalf("Failed to read audit log: %v", err)
+	}
+
+	var entries []audit.Entry
+	for _, entry := range allEntries {
+		if allProfiles || entry.Profile == appConfig.HarvestAPI.AccountID {
+			entries = append(entries, entry)
+		}
+	}
+
+	// Write to a file or the terminal
+	var out io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.OpenFile(output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		if err != nil {
+			log.Fatalf("Failed to create output file: %v", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	switch format {
+	case "json":
+		if entries == nil {
+			entries = []audit.Entry{}
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to format JSON: %v", err)
+		}
+		fmt.Fprintln(out, string(data))
+	case "csv":
+		if err := writeAuditCSV(out, entries); err != nil {
+			log.Fatalf("Failed to write CSV: %v", err)
+		}
+	default:
+		if len(entries) == 0 {
+			fmt.Fprintf(out, "No requests recorded from %s to %s\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
+			return
+		}
+		displayAuditEntries(out, entries)
+	}
+
+	if output != "" {
+		fmt.Printf("Exported %d audit log entries to %s\n", len(entries), output)
+	}
+}
+
+// displayAuditEntries prints audit log entries as a table
+func displayAuditEntries(out io.Writer, entries []audit.Entry) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Time\tProfile\tAction\tEntry ID\tStatus\tDetails")
+	fmt.Fprintln(w, "----\t-------\t------\t--------\t------\t-------")
+
+	for _, entry := range entries {
+		status := strconv.Itoa(entry.Status)
+		if entry.Status == 0 {
+			status = "-"
+		}
+
+		// Show the error, or the fields that were sent
+		details := entry.Error
+		if details == "" && entry.Status >= 400 {
+			details = auditErrorMessage(entry.Response)
+		}
+		if details == "" && len(entry.Request) > 0 {
+			var request struct {
+				SpentDate string   `json:"spent_date"`
+				Hours     *float64 `json:"hours"`
+				Notes     string   `json:"notes"`
+			}
+			if err := json.Unmarshal(entry.Request, &request); err == nil {
+				details = request.SpentDate
+				if request.Hours != nil {
+					hours, minutes := convertDecimalToHoursMinutes(*request.Hours)
+					details += fmt.Sprintf(" %02d:%02d", hours, minutes)
+				}
+				if request.Notes != "" {
+					details += " " + request.Notes
+				}
+			}
+		}
+		if len(details) > 50 {
+			details = details[:47] + "..."
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n",
+			entry.Time.Local().Format("2006-01-02 15:04:05"),
+			entry.Profile,
+			entry.Action,
+			entry.EntryID,
+			status,
+			details)
+	}
+
+	w.Flush()
+}
+
+// auditErrorMessage extracts the message of an API error response
+func auditErrorMessage(response json.RawMessage) string {
+	var errResp struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(response, &errResp); err != nil {
+		return ""
+	}
+	return errResp.Message
+}
+
+// writeAuditCSV writes audit log entries as CSV with the request and response as JSON columns
+func writeAuditCSV(out io.Writer, entries []audit.Entry) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"time", "profile", "action", "method", "url", "entry_id", "status", "error", "request", "response"}); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		record := []string{
+			entry.Time.Format(time.RFC3339),
+			entry.Profile,
+			entry.Action,
+			entry.Method,
+			entry.URL,
+			strconv.FormatInt(entry.EntryID, 10),
+			strconv.Itoa(entry.Status),
+			entry.Error,
+			string(entry.Request),
+			string(entry.Response),
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/calendar"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// BalancePeriod represents one row of the time balance ledger
+type BalancePeriod struct {
+	Label       string
+	From        time.Time
+	To          time.Time
+	Expected    float64
+	Billable    float64
+	Adjustments float64
+}
+
+// Difference returns the change of the balance within the period
+func (p BalancePeriod) Difference() float64 {
+	return p.Billable + p.Adjustments - p.Expected
+}
+
+// BalanceCmd returns the balance command
+func BalanceCmd() *cobra.Command {
+	var monthly bool
+	var to string
+
+	cmd := &cobra.Command{
+		Use:   "balance",
+		Short: "Show the running time balance",
+		Long: `Show a running time account of expected vs billable hours since balance_start_date.
+By default, shows one row per week.
+Use -m flag to show one row per month.
+Use --to flag to end the ledger at a specific date (YYYY-MM-DD format, default: today).
+
+The ledger starts with opening_balance_hours and carries the balance over into
+each new year (based on year_start_date in config).
+Manual corrections from balance_adjustments are added on their dates.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			// Parse the end date if provided
+			endDate := time.Now()
+			if to != "" {
+				var err error
+				endDate, err = time.ParseInLocation("2006-01-02", to, time.Local)
+				if err != nil {
+					log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+				}
+			}
+
+			handleBalance(client, endDate, monthly)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().BoolVarP(&monthly, "monthly", "m", false, "Show one row per month")
+	cmd.Flags().StringVar(&to, "to", "", "End date in YYYY-MM-DD format (default: today)")
+
+	cmd.AddCommand(balanceAdjustCmd())
+
+	return cmd
+}
+
+// balanceAdjustCmd returns the balance adjust subcommand
+func balanceAdjustCmd() *cobra.Command {
+	var date, reason string
+	var hours float64
+
+	cmd := &cobra.Command{
+		Use:   "adjust",
+		Short: "Record a manual balance adjustment",
+		Long: `Record a manual correction to the time balance in the configuration file.
+Example: h balance adjust --hours -8 --reason "Overtime paid out"
+Positive hours add to the balance, negative hours subtract from it.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if hours == 0 {
+				log.Fatalf("Please provide a non-zero value for --hours")
+			}
+
+			if date == "" {
+				date = time.Now().Format("2006-01-02")
+			} else if _, err := time.Parse("2006-01-02", date); err != nil {
+				log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+			}
+
+			appConfig.BalanceAdjustments = append(appConfig.BalanceAdjustments, config.BalanceAdjustment{
+				Date:   date,
+				Hours:  hours,
+				Reason: reason,
+			})
+
+			if err := appConfig.Save("balance_adjustments"); err != nil {
+				log.Fatalf("Failed to save configuration: %v", err)
+			}
+
+			fmt.Printf("Recorded adjustment of %+.2f hours on %s in %s\n", hours, date, appConfig.Path())
+		},
+	}
+
+	// Define flags
+	cmd.Flags().Float64Var(&hours, "hours", 0, "Hours to add (negative to subtract)")
+	cmd.Flags().StringVarP(&date, "date", "d", "", "Date in YYYY-MM-DD format (default: today)")
+	cmd.Flags().StringVarP(&reason, "reason", "r", "", "Reason for the adjustment")
+
+	return cmd
+}
+
+// handleBalance shows the time balance ledger up to the end date
+func handleBalance(client *harvest.Client, endDate time.Time, monthly bool) {
+	startDate, err := appConfig.GetBalanceStartDate()
+	if err != nil {
+		log.Fatalf("Failed to get balance start date: %v", err)
+	}
+
+	endDate = time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.Local)
+	if endDate.Before(startDate) {
+		log.Fatalf("End date %s is before balance_start_date %s", endDate.Format("2006-01-02"), appConfig.BalanceStartDate)
+	}
+
+	startMonth, startDay, err := appConfig.GetYearStartDate()
+	if err != nil {
+		log.Fatalf("Failed to get year start date: %v", err)
+	}
+
+	// Get time entries for the whole ledger
+	params := map[string]string{
+		"from": startDate.Format("2006-01-02"),
+		"to":   endDate.Format("2006-01-02"),
+	}
+
+	fmt.Printf("Fetching time entries from %s to %s...\n", params["from"], params["to"])
+	timeEntries, err := client.GetAllTimeEntries(params)
+	if err != nil {
+		log.Fatalf("Failed to get time entries: %v", err)
+	}
+
+	// Aggregate billable hours and adjustments by date
+	billableByDate := make(map[string]float64)
+	for _, entry := range timeEntries {
+		if appConfig.IsBillableEntry(int(entry.Task.ID), entry.Billable) {
+			billableByDate[entry.SpentDate] += entry.Hours
+		}
+	}
+
+	openingBalance := appConfig.OpeningBalanceHours
+	adjustmentsByDate := make(map[string]float64)
+	for _, adjustment := range appConfig.BalanceAdjustments {
+		if _, err := time.Parse("2006-01-02", adjustment.Date); err != nil {
+			log.Fatalf("Invalid balance adjustment date: %s, expected YYYY-MM-DD", adjustment.Date)
+		}
+
+		// Adjustments before the ledger starts are part of the opening balance
+		if adjustment.Date < params["from"] {
+			openingBalance += adjustment.Hours
+			continue
+		}
+		adjustmentsByDate[adjustment.Date] += adjustment.Hours
+	}
+
+	cal := loadCalendar()
+	periods := buildBalancePeriods(cal, startDate, endDate, monthly, startMonth, startDay, billableByDate, adjustmentsByDate)
+
+	// Display the ledger
+	fmt.Printf("\nTime Balance (%s to %s):\n", params["from"], params["to"])
+	fmt.Printf("Opening Balance: %+.2f hours\n\n", openingBalance)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Period\tExpected\tBillable\tAdjustments\tDifference\tBalance")
+	fmt.Fprintln(w, "------\t--------\t--------\t-----------\t----------\t-------")
+
+	balance := openingBalance
+	yearStart := fiscalYearStart(startDate, startMonth, startDay)
+	for _, period := range periods {
+		// Carry the balance over into the next year
+		if nextYearStart := fiscalYearStart(period.From, startMonth, startDay); nextYearStart.After(yearStart) {
+			fmt.Fprintf(w, "Carried over to %s\t\t\t\t\t%+.2f\n",
+				fiscalYearLabel(nextYearStart, startMonth, startDay),
+				balance)
+			yearStart = nextYearStart
+		}
+
+		balance += period.Difference()
+		fmt.Fprintf(w, "%s\t%.2f\t%.2f\t%+.2f\t%+.2f\t%+.2f\n",
+			period.Label,
+			period.Expected,
+			period.Billable,
+			period.Adjustments,
+			period.Difference(),
+			balance)
+	}
+
+	w.Flush()
+
+	// Convert the balance to days using the average working day since the start
+	dailyHours := cal.Period(startDate, endDate).AverageDailyHours()
+	if dailyHours <= 0 {
+		dailyHours = appConfig.GetDailyHours()
+	}
+	fmt.Printf("\nCurrent Balance: %+.2f hours (%+.2f days)\n", balance, balance/dailyHours)
+}
+
+// buildBalancePeriods splits the ledger into weeks or months, never crossing a year boundary
+func buildBalancePeriods(cal *calendar.Calendar, startDate, endDate time.Time, monthly bool, startMonth, startDay int, billableByDate, adjustmentsByDate map[string]float64) []BalancePeriod {
+	var periods []BalancePeriod
+	for from := startDate; !from.After(endDate); {
+		var to time.Time
+		if monthly {
+			to = time.Date(from.Year(), from.Month()+1, 1, 0, 0, 0, 0, from.Location()).AddDate(0, 0, -1)
+		} else {
+			// Weeks end on Sunday
+			weekday := int(from.Weekday())
+			if weekday == 0 {
+				weekday = 7
+			}
+			to = from.AddDate(0, 0, 7-weekday)
+		}
+
+		// Split periods at the start of a new year and at the end date
+		nextYearStart := fiscalYearStart(from, startMonth, startDay).AddDate(1, 0, 0)
+		if !to.Before(nextYearStart) {
+			to = nextYearStart.AddDate(0, 0, -1)
+		}
+		if to.After(endDate) {
+			to = endDate
+		}
+
+		period := BalancePeriod{
+			From:     from,
+			To:       to,
+			Expected: cal.Period(from, to).Capacity,
+		}
+		if monthly {
+			period.Label = from.Format("January 2006")
+		} else {
+			period.Label = fmt.Sprintf("%s to %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+		}
+
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			key := day.Format("2006-01-02")
+			period.Billable += billableByDate[key]
+			period.Adjustments += adjustmentsByDate[key]
+		}
+
+		periods = append(periods, period)
+		from = to.AddDate(0, 0, 1)
+	}
+
+	return periods
+}
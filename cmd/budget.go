@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// BudgetCmd returns the budget command
+func BudgetCmd() *cobra.Command {
+	var weeks int
+
+	cmd := &cobra.Command{
+		Use:   "budget [project]",
+		Short: "Show project budgets",
+		Long: `Show budget, spent, remaining and burn rate of active projects with a budget.
+Example: h budget "Project A"
+
+Optionally filter by project name (case-insensitive substring) or project ID.
+The burn rate is the average spent per week by the whole team over the last weeks (default: 4).
+Requires permission to view the project budget and time reports in Harvest.`,
+		Args: cobra.MaximumNArgs(1),
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			var filter string
+			if len(args) > 0 {
+				filter = args[0]
+			}
+
+			if weeks < 1 {
+				log.Fatalf("Invalid number of weeks: %d", weeks)
+			}
+
+			handleBudget(client, filter, weeks)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().IntVarP(&weeks, "weeks", "w", 4, "Number of past weeks used to calculate the burn rate")
+
+	return cmd
+}
+
+// handleBudget shows the budget report for projects matching the filter
+func handleBudget(client *harvest.Client, filter string, weeks int) {
+	fmt.Println("Fetching project budgets...")
+	budgets, err := client.GetProjectBudgetReport(map[string]string{"is_active": "true"})
+	if err != nil {
+		log.Fatalf("Failed to get project budgets: %v", err)
+	}
+
+	// Keep projects with a budget that match the filter
+	var matched []harvest.ProjectBudget
+	for _, budget := range budgets {
+		if budget.BudgetBy == "none" || budget.Budget <= 0 {
+			continue
+		}
+		if filter != "" && !matchesProjectFilter(budget.ProjectID, budget.ProjectName, "", filter) {
+			continue
+		}
+		matched = append(matched, budget)
+	}
+
+	if len(matched) == 0 {
+		if filter != "" {
+			fmt.Printf("No budgeted projects found matching '%s'\n", filter)
+		} else {
+			fmt.Println("No budgeted projects found")
+		}
+		return
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].ProjectName < matched[j].ProjectName
+	})
+
+	// Calculate the burn rate from the time of the whole team in the last weeks,
+	// like the spent part of the budget
+	to := time.Now()
+	from := to.AddDate(0, 0, -7*weeks)
+
+	fmt.Printf("Fetching project time from %s to %s...\n", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	results, err := client.GetProjectTimeReport(from, to)
+	if err != nil {
+		log.Fatalf("Failed to get project time report: %v", err)
+	}
+
+	hoursByProject := make(map[int64]float64)
+	amountByProject := make(map[int64]float64)
+	for _, result := range results {
+		hoursByProject[result.ProjectID] += result.TotalHours
+		amountByProject[result.ProjectID] += result.BillableAmount
+	}
+
+	// Display the budget report
+	fmt.Printf("\nProject Budgets (burn rate over the last %d weeks):\n", weeks)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Project\tClient\tBudget\tSpent\tRemaining\t% Used\tBurn/Week\tWeeks Left")
+	fmt.Fprintln(w, "-------\t------\t------\t-----\t---------\t------\t---------\t----------")
+
+	for _, budget := range matched {
+		var burnRate float64
+		if budget.IsHourBudget() {
+			burnRate = hoursByProject[budget.ProjectID] / float64(weeks)
+		} else {
+			burnRate = amountByProject[budget.ProjectID] / float64(weeks)
+		}
+
+		weeksLeft := "-"
+		if burnRate > 0 && budget.BudgetRemaining > 0 {
+			weeksLeft = fmt.Sprintf("%.1f", budget.BudgetRemaining/burnRate)
+		} else if budget.BudgetRemaining <= 0 {
+			weeksLeft = "over budget"
+		}
+
+		name := budget.ProjectName
+		if budget.BudgetIsMonthly {
+			name += " (monthly)"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%.1f%%\t%s\t%s\n",
+			name,
+			budget.ClientName,
+			formatBudgetValue(budget, budget.Budget),
+			formatBudgetValue(budget, budget.BudgetSpent),
+			formatBudgetValue(budget, budget.BudgetRemaining),
+			(budget.BudgetSpent/budget.Budget)*100,
+			formatBudgetValue(budget, burnRate),
+			weeksLeft)
+	}
+
+	w.Flush()
+}
+
+// matchesProjectFilter checks a project against a project ID, its code or a name substring
+func matchesProjectFilter(projectID int64, name, code, filter string) bool {
+	if id, err := strconv.ParseInt(filter, 10, 64); err == nil && id == projectID {
+		return true
+	}
+	if code != "" && strings.EqualFold(code, filter) {
+		return true
+	}
+	return strings.Contains(strings.ToLower(name), strings.ToLower(filter))
+}
+
+// formatBudgetValue formats a value in hours or in the configured currency, depending on the budget type
+func formatBudgetValue(budget harvest.ProjectBudget, value float64) string {
+	if budget.IsHourBudget() {
+		return fmt.Sprintf("%.2fh", value)
+	}
+	return formatAmount(value)
+}
+
+// confirmProjectBudget warns when logging hours would push a project past the
+// configured budget threshold and asks whether to continue
+func confirmProjectBudget(client *harvest.Client, projectID int, hours float64) bool {
+	threshold := appConfig.BudgetWarningThreshold
+	if threshold <= 0 {
+		return true // Budget warnings are disabled
+	}
+
+	budget, err := client.GetProjectBudget(int64(projectID))
+	if err != nil {
+		fmt.Printf("Could not check project budget: %v\n", err)
+		return true
+	}
+	if budget == nil || budget.BudgetBy == "none" || budget.Budget <= 0 {
+		return true
+	}
+
+	// Only hour budgets can include the new entry, fee budgets use the amount spent so far
+	spent := budget.BudgetSpent
+	if budget.IsHourBudget() {
+		spent += hours
+	}
+
+	percentUsed := (spent / budget.Budget) * 100
+	if percentUsed < threshold {
+		return true
+	}
+
+	fmt.Printf("\nWarning: project '%s' will be at %.1f%% of its budget (%s of %s, threshold: %.0f%%)\n",
+		budget.ProjectName,
+		percentUsed,
+		formatBudgetValue(*budget, spent),
+		formatBudgetValue(*budget, budget.Budget),
+		threshold)
+
+	confirmPrompt := promptui.Select{
+		Label: "What would you like to do?",
+		Items: []string{"Create time entry anyway", "Cancel"},
+	}
+
+	confirmIndex, _, err := confirmPrompt.Run()
+	if err != nil {
+		log.Fatalf("Prompt failed: %v", err)
+	}
+
+	return confirmIndex == 0
+}
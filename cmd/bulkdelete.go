@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"harvest-cli/pkg/harvest"
+	"harvest-cli/pkg/journal"
+	"harvest-cli/pkg/queue"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// bulkDeleteOptions holds the flags of a delete by filter and date range
+type bulkDeleteOptions struct {
+	From    string
+	To      string
+	Filter  entryFilter
+	Workers int
+	Rate    float64 // Delete requests per second
+	Yes     bool
+}
+
+// handleBulkDelete deletes all unlocked entries between two dates that match the filter,
+// after writing them to a restore file
+func handleBulkDelete(client *harvest.Client, opts bulkDeleteOptions) {
+	fmt.Printf("Fetching time entries from %s to %s...\n", opts.From, opts.To)
+	timeEntries, err := client.GetAllTimeEntries(timeEntryParams(opts.From, opts.To))
+	if err != nil {
+		log.Fatalf("Failed to get time entries: %v", err)
+	}
+
+	var selectedEntries []harvest.TimeEntry
+	var lockedCount int
+	for _, entry := range timeEntries {
+		if !opts.Filter.Matches(entry) {
+			continue
+		}
+		if checkEntryUnlocked(&entry, "deleted") != nil {
+			lockedCount++
+			continue
+		}
+		selectedEntries = append(selectedEntries, entry)
+	}
+
+	if len(selectedEntries) == 0 {
+		fmt.Printf("No time entries to delete between %s and %s", opts.From, opts.To)
+		if lockedCount > 0 {
+			fmt.Printf(" (%d matching entries are locked)", lockedCount)
+		}
+		fmt.Println()
+		return
+	}
+
+	displayDeleteSummary(selectedEntries, lockedCount)
+
+	if !opts.Yes {
+		fmt.Printf("Are you sure you want to delete these %d entries? (y/n): ", len(selectedEntries))
+		reader := bufio.NewReader(os.Stdin)
+		confirm, _ := reader.ReadString('\n')
+		confirm = strings.TrimSpace(strings.ToLower(confirm))
+
+		if confirm != "y" && confirm != "yes" {
+			fmt.Println("Deletion cancelled")
+			return
+		}
+	}
+
+	// Keep a copy of the entries before anything is deleted
+	restorePath, err := writeRestoreFile(selectedEntries)
+	if err != nil {
+		log.Fatalf("Failed to write restore file, nothing was deleted: %v", err)
+	}
+	fmt.Printf("Saved the entries to %s\n\n", restorePath)
+
+	// Delete concurrently without exceeding the request rate, results are reported in order
+	errs := make([]error, len(selectedEntries))
+	limiter := newRateLimiter(opts.Rate)
+	runConcurrently(len(selectedEntries), opts.Workers, func(i int) {
+		id := selectedEntries[i].ID
+		errs[i] = withRetry(limiter, fmt.Sprintf("time entry %d", id), func() error {
+			return client.DeleteTimeEntry(id)
+		})
+	})
+	limiter.Stop()
+
+	var successCount, failCount, queuedCount int
+	var removedEntries []harvest.TimeEntry
+	for i, entry := range selectedEntries {
+		err := errs[i]
+		if harvest.IsNetworkError(err) {
+			// Keep the delete for "h sync", the entry still exists until then
+			queueOperation(queue.ActionDelete, entry.ID, nil, &selectedEntries[i], err)
+			queuedCount++
+		} else if err != nil {
+			fmt.Printf("Failed to delete time entry %d: %v\n", entry.ID, err)
+			failCount++
+		} else {
+			fmt.Printf("Time entry %d deleted successfully\n", entry.ID)
+			recordOperation(journal.ActionDelete, &selectedEntries[i], nil)
+			removedEntries = append(removedEntries, entry)
+			successCount++
+		}
+	}
+
+	// Entries that are still in Harvest must not be recreated by a restore
+	if len(removedEntries) == 0 {
+		if err := os.Remove(restorePath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove restore file: %v\n", err)
+		}
+	} else if len(removedEntries) < len(selectedEntries) {
+		if err := saveRestoreFile(restorePath, removedEntries); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update restore file: %v\n", err)
+		}
+	}
+
+	// Summary
+	fmt.Println("\nDeletion Summary:")
+	fmt.Println("-----------------------------------")
+	fmt.Printf("Total: %d entries\n", len(selectedEntries))
+	fmt.Printf("Successful: %d\n", successCount)
+	fmt.Printf("Failed: %d\n", failCount)
+	if queuedCount > 0 {
+		fmt.Printf("Queued for sync: %d\n", queuedCount)
+	}
+	fmt.Println("-----------------------------------")
+	if successCount > 0 {
+		fmt.Printf("Restore the deleted entries with: h delete --restore %s\n", restorePath)
+	}
+	if queuedCount > 0 {
+		fmt.Println("Queued deletes are not in the restore file, use \"h sync --discard\" to keep those entries")
+	}
+}
+
+// displayDeleteSummary prints the number of entries and hours to delete per project and task
+func displayDeleteSummary(entries []harvest.TimeEntry, lockedCount int) {
+	type group struct {
+		Name  string
+		Count int
+		Hours float64
+	}
+
+	groups := make(map[string]*group)
+	var totalHours float64
+	firstDate, lastDate := entries[0].SpentDate, entries[0].SpentDate
+	for _, entry := range entries {
+		name := entry.Project.Name + " | " + entry.Task.Name
+		if groups[name] == nil {
+			groups[name] = &group{Name: name}
+		}
+		groups[name].Count++
+		groups[name].Hours += entry.Hours
+		totalHours += entry.Hours
+
+		if entry.SpentDate < firstDate {
+			firstDate = entry.SpentDate
+		}
+		if entry.SpentDate > lastDate {
+			lastDate = entry.SpentDate
+		}
+	}
+
+	var sorted []*group
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	fmt.Printf("\nTime entries to delete (%s to %s):\n\n", firstDate, lastDate)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Project | Task\tEntries\tDuration")
+	fmt.Fprintln(w, "--------------\t-------\t--------")
+	for _, g := range sorted {
+		hours, minutes := convertDecimalToHoursMinutes(g.Hours)
+		fmt.Fprintf(w, "%s\t%d\t%02d:%02d\n", g.Name, g.Count, hours, minutes)
+	}
+	w.Flush()
+
+	hours, minutes := convertDecimalToHoursMinutes(totalHours)
+	fmt.Printf("\nTotal: %d entries, %02d:%02d hours\n", len(entries), hours, minutes)
+	if lockedCount > 0 {
+		fmt.Printf("%d matching entries are locked and will be kept\n", lockedCount)
+	}
+	fmt.Println()
+}
+
+// writeRestoreFile saves entries about to be deleted to a new file in the data directory
+func writeRestoreFile(entries []harvest.TimeEntry) (string, error) {
+	dataDir, err := appConfig.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dataDir, fmt.Sprintf("deleted-%s.json", time.Now().Format("20060102-150405")))
+	if err := saveRestoreFile(path, entries); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// saveRestoreFile writes time entries to a restore file
+func saveRestoreFile(path string, entries []harvest.TimeEntry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode time entries: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// handleRestore recreates the time entries saved in a restore file, the recreated entries get new IDs
+func handleRestore(client *harvest.Client, path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("Failed to read restore file: %v", err)
+	}
+
+	var entries []harvest.TimeEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		log.Fatalf("Failed to parse restore file: %v", err)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No time entries to restore")
+		return
+	}
+
+	var totalHours float64
+	for _, entry := range entries {
+		totalHours += entry.Hours
+	}
+	hours, minutes := convertDecimalToHoursMinutes(totalHours)
+	fmt.Printf("Restoring %d entries (%02d:%02d hours) from %s\n\n", len(entries), hours, minutes, path)
+
+	var successCount, failCount int
+	for _, entry := range entries {
+		created, err := client.CreateTimeEntry(entryRequest(&entry))
+		if err != nil {
+			fmt.Printf("Failed to recreate time entry %d: %v\n", entry.ID, err)
+			failCount++
+			continue
+		}
+		fmt.Printf("Time entry %d recreated as time entry %d\n", entry.ID, created.ID)
+		recordOperation(journal.ActionCreate, nil, created)
+		successCount++
+	}
+
+	// Summary
+	fmt.Println("\nRestore Summary:")
+	fmt.Println("-----------------------------------")
+	fmt.Printf("Total: %d entries\n", len(entries))
+	fmt.Printf("Successful: %d\n", successCount)
+	fmt.Printf("Failed: %d\n", failCount)
+	fmt.Println("-----------------------------------")
+}
@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"harvest-cli/pkg/journal"
+	"harvest-cli/pkg/queue"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// bulkChange is a planned update of a time entry by the bulk-update command
+type bulkChange struct {
+	Entry   harvest.TimeEntry
+	Request *harvest.TimeEntry       // Full entry after the change, queued if Harvest is unreachable
+	Update  *harvest.TimeEntryUpdate // Changed fields sent to Harvest
+	Project *config.Project          // Target project
+	Task    *config.Task             // Target task
+}
+
+// bulkUpdateOptions holds the flags of the bulk-update command
+type bulkUpdateOptions struct {
+	From        string
+	To          string
+	Filter      entryFilter
+	Assignments map[string]string // New "project" and/or "task"
+	Workers     int
+	Rate        float64 // Update requests per second
+	Yes         bool
+}
+
+// BulkUpdateCmd returns the bulk-update command
+func BulkUpdateCmd() *cobra.Command {
+	var from, to, where, set string
+	var workers int
+	var rate float64
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "bulk-update",
+		Short: "Move many time entries to another project or task",
+		Long: `Reassign all time entries between two dates that match a filter to another project or task.
+Example: h bulk-update --from 2024-01-01 --to 2024-03-31 --where "project=Old Project,task=Design" --set "project=New Project,task=Design"
+
+Use --where flag to select entries by project, task (name or ID) and notes (case-insensitive pattern).
+Use --set flag with the project and/or task to move the entries to. When only the project is set,
+each entry keeps its task in the new project; when only the task is set, entries stay in their project.
+The matched entries are shown for confirmation before the updates are sent concurrently,
+within Harvest's rate limit. Use -w flag to change the number of concurrent requests,
+--rate flag to change the number of requests per second and -y flag to skip the confirmation.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if to == "" {
+				to = time.Now().Format("2006-01-02")
+			}
+			for _, value := range []string{from, to} {
+				if _, err := time.Parse("2006-01-02", value); err != nil {
+					log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+				}
+			}
+			if to < from {
+				log.Fatalf("The to date must not be before the from date")
+			}
+
+			filter, err := parseWhereFilter(where)
+			if err != nil {
+				log.Fatalf("Invalid --where: %v", err)
+			}
+			if filter.IsEmpty() {
+				log.Fatalf("--where must select entries by project, task or notes")
+			}
+
+			assignments, err := parseAssignments(set, "project", "task")
+			if err != nil {
+				log.Fatalf("Invalid --set: %v", err)
+			}
+			if len(assignments) == 0 {
+				log.Fatalf("--set must change the project, the task or both")
+			}
+
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			handleBulkUpdate(client, bulkUpdateOptions{
+				From:        from,
+				To:          to,
+				Filter:      filter,
+				Assignments: assignments,
+				Workers:     workers,
+				Rate:        rate,
+				Yes:         yes,
+			})
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringVar(&from, "from", "", "Start date in YYYY-MM-DD format")
+	cmd.Flags().StringVar(&to, "to", "", "End date in YYYY-MM-DD format (default: today)")
+	cmd.Flags().StringVar(&where, "where", "", "Entries to update, e.g. \"project=Old Project,task=Design,notes=review\"")
+	cmd.Flags().StringVar(&set, "set", "", "New project and/or task, e.g. \"project=New Project,task=Design\"")
+	cmd.Flags().IntVarP(&workers, "workers", "w", 4, "Number of updates sent at the same time")
+	cmd.Flags().Float64Var(&rate, "rate", 5, "Maximum number of updates sent per second")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Update without asking for confirmation")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("where")
+	cmd.MarkFlagRequired("set")
+
+	return cmd
+}
+
+// parseWhereFilter parses a --where value such as "project=Project A,task=Meetings,notes=standup"
+func parseWhereFilter(value string) (entryFilter, error) {
+	var filter entryFilter
+
+	assignments, err := parseAssignments(value, "project", "task", "notes")
+	if err != nil {
+		return filter, err
+	}
+
+	filter.Project = assignments["project"]
+	filter.Task = assignments["task"]
+	if pattern, ok := assignments["notes"]; ok {
+		if filter.Notes, err = compileNotesPattern(pattern); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, nil
+}
+
+// handleBulkUpdate previews the entries matching the filter and moves them to the new project or task
+func handleBulkUpdate(client *harvest.Client, opts bulkUpdateOptions) {
+	assignments := opts.Assignments
+
+	// The target project is the same for all entries when it is set
+	var setProject *config.Project
+	if name, ok := assignments["project"]; ok {
+		setProject = appConfig.GetProjectByName(name)
+		if setProject == nil {
+			log.Fatalf("Project '%s' not found in configuration", name)
+		}
+		if taskName, ok := assignments["task"]; ok && setProject.GetTaskByName(taskName) == nil {
+			log.Fatalf("Task '%s' not found in project '%s'", taskName, setProject.Name)
+		}
+	}
+
+	fmt.Printf("Fetching time entries from %s to %s...\n", opts.From, opts.To)
+	timeEntries, err := client.GetAllTimeEntries(timeEntryParams(opts.From, opts.To))
+	if err != nil {
+		log.Fatalf("Failed to get time entries: %v", err)
+	}
+
+	var changes []bulkChange
+	var skipped []string
+	var unchanged int
+	for _, entry := range timeEntries {
+		if !opts.Filter.Matches(entry) {
+			continue
+		}
+
+		if err := checkEntryUnlocked(&entry, "updated"); err != nil {
+			skipped = append(skipped, fmt.Sprintf("%d (%s): %v", entry.ID, entry.SpentDate, err))
+			continue
+		}
+
+		project := setProject
+		if project == nil {
+			project = appConfig.GetProjectByID(int(entry.Project.ID))
+			if project == nil {
+				skipped = append(skipped, fmt.Sprintf("%d (%s): project '%s' not found in configuration",
+					entry.ID, entry.SpentDate, entry.Project.Name))
+				continue
+			}
+		}
+
+		// Without a new task, entries keep their task in the target project
+		var task *config.Task
+		if name, ok := assignments["task"]; ok {
+			task = project.GetTaskByName(name)
+		} else if task = project.GetTaskByID(int(entry.Task.ID)); task == nil {
+			task = project.GetTaskByName(entry.Task.Name)
+		}
+		if task == nil {
+			taskName := assignments["task"]
+			if taskName == "" {
+				taskName = entry.Task.Name
+			}
+			skipped = append(skipped, fmt.Sprintf("%d (%s): task '%s' not found in project '%s'",
+				entry.ID, entry.SpentDate, taskName, project.Name))
+			continue
+		}
+
+		request := entryRequest(&entry)
+		request.ProjectID = project.ID
+		request.TaskID = task.ID
+
+		update := harvest.DiffTimeEntry(&entry, request)
+		if update.IsEmpty() {
+			unchanged++
+			continue
+		}
+
+		changes = append(changes, bulkChange{Entry: entry, Request: request, Update: update, Project: project, Task: task})
+	}
+
+	if len(changes) == 0 {
+		fmt.Printf("No time entries to update (%d already match, %d skipped)\n", unchanged, len(skipped))
+		for _, reason := range skipped {
+			fmt.Printf("  Skipped %s\n", reason)
+		}
+		return
+	}
+
+	displayBulkChanges(changes)
+
+	if unchanged > 0 {
+		fmt.Printf("%d matching entries already have these values\n", unchanged)
+	}
+	if len(skipped) > 0 {
+		fmt.Printf("%d matching entries will be skipped:\n", len(skipped))
+		for _, reason := range skipped {
+			fmt.Printf("  %s\n", reason)
+		}
+	}
+
+	if !opts.Yes {
+		fmt.Printf("\nAre you sure you want to update these %d entries? (y/n): ", len(changes))
+		reader := bufio.NewReader(os.Stdin)
+		confirm, _ := reader.ReadString('\n')
+		confirm = strings.TrimSpace(strings.ToLower(confirm))
+
+		if confirm != "y" && confirm != "yes" {
+			fmt.Println("Update cancelled")
+			return
+		}
+	}
+
+	// Send the updates concurrently without exceeding the request rate,
+	// results are reported in the order of the preview
+	updated := make([]*harvest.TimeEntry, len(changes))
+	errs := make([]error, len(changes))
+	fmt.Println()
+	limiter := newRateLimiter(opts.Rate)
+	runConcurrently(len(changes), opts.Workers, func(i int) {
+		change := changes[i]
+		errs[i] = withRetry(limiter, fmt.Sprintf("time entry %d", change.Entry.ID), func() error {
+			var err error
+			updated[i], err = client.UpdateTimeEntry(change.Entry.ID, change.Update)
+			return err
+		})
+	})
+	limiter.Stop()
+
+	var successCount, failCount, queuedCount int
+	for i, change := range changes {
+		err := errs[i]
+		if harvest.IsNetworkError(err) {
+			// Keep the update for "h sync"
+			queueOperation(queue.ActionUpdate, change.Entry.ID, change.Request, &changes[i].Entry, err)
+			queuedCount++
+		} else if err != nil {
+			fmt.Printf("Failed to update time entry %d: %v\n", change.Entry.ID, err)
+			failCount++
+		} else {
+			fmt.Printf("Time entry %d updated successfully\n", change.Entry.ID)
+			recordOperation(journal.ActionUpdate, &changes[i].Entry, updated[i])
+			successCount++
+		}
+	}
+
+	// Summary
+	fmt.Println("\nUpdate Summary:")
+	fmt.Println("-----------------------------------")
+	fmt.Printf("Total: %d entries\n", len(changes))
+	fmt.Printf("Successful: %d\n", successCount)
+	fmt.Printf("Failed: %d\n", failCount)
+	if queuedCount > 0 {
+		fmt.Printf("Queued for sync: %d\n", queuedCount)
+	}
+	if len(skipped) > 0 {
+		fmt.Printf("Skipped: %d\n", len(skipped))
+	}
+	fmt.Println("-----------------------------------")
+}
+
+// displayBulkChanges prints the planned updates with the current and new project and task
+func displayBulkChanges(changes []bulkChange) {
+	fmt.Printf("\nTime entries to update (%d):\n\n", len(changes))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDate\tFrom\tTo\tDuration\tNotes")
+	fmt.Fprintln(w, "--\t----\t----\t--\t--------\t-----")
+
+	var totalHours float64
+	for _, change := range changes {
+		entry := change.Entry
+		hours, minutes := convertDecimalToHoursMinutes(entry.Hours)
+		fmt.Fprintf(w, "%d\t%s\t%s | %s\t%s | %s\t%02d:%02d\t%s\n",
+			entry.ID,
+			entry.SpentDate,
+			entry.Project.Name,
+			entry.Task.Name,
+			change.Project.Name,
+			change.Task.Name,
+			hours,
+			minutes,
+			entry.Notes)
+		totalHours += entry.Hours
+	}
+
+	w.Flush()
+
+	hours, minutes := convertDecimalToHoursMinutes(totalHours)
+	fmt.Printf("\nTotal: %02d:%02d hours\n", hours, minutes)
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/cache"
+	"harvest-cli/pkg/harvest"
+	"time"
+)
+
+// noCache bypasses the time entry cache
+var noCache bool
+
+// refreshCache discards cached time entries and fetches them again in full
+var refreshCache bool
+
+// cacheRefreshMargin is subtracted from the last refresh to allow for clock differences with Harvest
+const cacheRefreshMargin = 5 * time.Minute
+
+// fetchTimeEntries returns the time entries for the parameters, using the cache in the data directory.
+// Cached ranges are refreshed with the entries updated since the last refresh, and fetched again
+// in full once they are older than cache_max_age_hours. While Harvest is unreachable, cached
+// entries are returned as they are.
+func fetchTimeEntries(client *harvest.Client, params map[string]string) ([]harvest.TimeEntry, error) {
+	if noCache {
+		return client.GetAllTimeEntries(params)
+	}
+
+	dataDir, err := appConfig.GetDataDir()
+	if err != nil {
+		fmt.Fprintf(warningOutput, "Warning: time entry cache disabled: %v\n", err)
+		return client.GetAllTimeEntries(params)
+	}
+	c := cache.Open(dataDir)
+	accountID := appConfig.HarvestAPI.AccountID
+
+	cached, err := c.Load(accountID, params)
+	if err != nil {
+		fmt.Fprintf(warningOutput, "Warning: ignoring time entry cache: %v\n", err)
+		cached = nil
+	}
+
+	now := time.Now()
+	if cached != nil && !refreshCache && now.Sub(cached.FetchedAt) < appConfig.GetCacheMaxAge() {
+		// Only fetch the entries updated since the last refresh, without the date range
+		// so entries moved out of the range are noticed too
+		updatedParams := harvest.CopyParams(params)
+		delete(updatedParams, "from")
+		delete(updatedParams, "to")
+		updatedParams["updated_since"] = cached.RefreshedAt.Add(-cacheRefreshMargin).UTC().Format(time.RFC3339)
+
+		updated, err := client.GetAllTimeEntries(updatedParams)
+		if harvest.IsNetworkError(err) {
+			warnCachedEntries(cached, err)
+			return cached.Entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cached.Merge(updated)
+		cached.RefreshedAt = now
+	} else {
+		entries, err := client.GetAllTimeEntries(params)
+		if harvest.IsNetworkError(err) && cached != nil {
+			warnCachedEntries(cached, err)
+			return cached.Entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cached = &cache.Range{
+			AccountID:   accountID,
+			Params:      params,
+			FetchedAt:   now,
+			RefreshedAt: now,
+			Entries:     entries,
+		}
+	}
+
+	if err := c.Save(cached); err != nil {
+		fmt.Fprintf(warningOutput, "Warning: failed to update time entry cache: %v\n", err)
+	}
+
+	return cached.Entries, nil
+}
+
+// warnCachedEntries explains that cached entries are shown because Harvest is unreachable
+func warnCachedEntries(cached *cache.Range, err error) {
+	fmt.Fprintf(warningOutput, "Warning: Harvest is unreachable, showing cached time entries from %s: %v\n",
+		cached.RefreshedAt.Local().Format("2006-01-02 15:04"), err)
+}
+
+// invalidateCache drops the cached time entries of the current profile after a change in Harvest
+func invalidateCache() {
+	dataDir, err := appConfig.GetDataDir()
+	if err == nil {
+		err = cache.Open(dataDir).Invalidate(appConfig.HarvestAPI.AccountID)
+	}
+	if err != nil {
+		fmt.Fprintf(warningOutput, "Warning: failed to clear time entry cache: %v\n", err)
+	}
+}
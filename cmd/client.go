@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/audit"
+	"harvest-cli/pkg/harvest"
+	"io"
+	"os"
+)
+
+// warningOutput receives warnings about local bookkeeping that don't fail a command,
+// such as journal and cache errors
+var warningOutput io.Writer = os.Stderr
+
+// newHarvestClient creates a Harvest API client that records time entry mutations in the audit log
+func newHarvestClient() *harvest.Client {
+	client := harvest.NewClient(&appConfig.HarvestAPI)
+
+	dataDir, err := appConfig.GetDataDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: audit log disabled: %v\n", err)
+		return client
+	}
+	client.SetAuditLog(audit.Open(dataDir))
+
+	return client
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/harvest"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/manifoldco/promptui"
+)
+
+// resolveClockRange returns the start and end of a time entry from the --start and --end flags,
+// or one of them and a duration in HH:MM format
+func resolveClockRange(startValue, endValue, timeValue string) (time.Time, time.Time, error) {
+	var start, end time.Time
+	var err error
+
+	if startValue != "" {
+		if start, err = harvest.ParseClockTime(startValue); err != nil {
+			return start, end, err
+		}
+	}
+	if endValue != "" {
+		if end, err = harvest.ParseClockTime(endValue); err != nil {
+			return start, end, err
+		}
+	}
+
+	switch {
+	case startValue != "" && endValue != "":
+		if timeValue != "" {
+			return start, end, fmt.Errorf("use either --end or -t with --start, not both")
+		}
+	case timeValue == "":
+		return start, end, fmt.Errorf("--start and --end must be used together or with -t")
+	default:
+		hours, err := parseDuration(timeValue)
+		if err != nil {
+			return start, end, fmt.Errorf("invalid duration format: %w", err)
+		}
+		duration := time.Duration(hours * float64(time.Hour)).Round(time.Minute)
+
+		if startValue != "" {
+			end = start.Add(duration)
+		} else {
+			start = end.Add(-duration)
+		}
+	}
+
+	// Entries end on the day they start
+	if !end.After(start) || end.Day() != start.Day() {
+		return start, end, fmt.Errorf("the end time must be after the start time on the same day")
+	}
+
+	return start, end, nil
+}
+
+// clockHours returns the duration between two wall clock times in decimal hours
+func clockHours(start, end time.Time) float64 {
+	return end.Sub(start).Minutes() / 60
+}
+
+// moveEndTime moves the end time of an update request with wall clock times so it matches
+// the requested duration, as long as the entry still ends on the same day
+func moveEndTime(request, entry *harvest.TimeEntry) {
+	start, _, ok := entry.ClockRange()
+	if !ok {
+		return
+	}
+
+	end := start.Add(time.Duration(request.Hours * float64(time.Hour)).Round(time.Minute))
+	if end.Day() == start.Day() {
+		request.EndedTime = harvest.FormatClockTime(end)
+	}
+}
+
+// formatClockRange formats the wall clock times of an entry as "HH:MM", or "-" if it has none
+func formatClockRange(entry harvest.TimeEntry) (string, string) {
+	start, end, ok := entry.ClockRange()
+	if !ok {
+		return "-", "-"
+	}
+	return start.Format("15:04"), end.Format("15:04")
+}
+
+// overlappingEntries returns the pairs of indices of entries whose wall clock times overlap
+func overlappingEntries(entries []harvest.TimeEntry) [][2]int {
+	var pairs [][2]int
+
+	for i := range entries {
+		startA, endA, ok := entries[i].ClockRange()
+		if !ok {
+			continue
+		}
+
+		for j := i + 1; j < len(entries); j++ {
+			if entries[i].SpentDate != entries[j].SpentDate {
+				continue
+			}
+			startB, endB, ok := entries[j].ClockRange()
+			if !ok {
+				continue
+			}
+
+			if startA.Before(endB) && startB.Before(endA) {
+				pairs = append(pairs, [2]int{i, j})
+			}
+		}
+	}
+
+	return pairs
+}
+
+// displayOverlaps warns about the entries whose wall clock times overlap
+func displayOverlaps(entries []harvest.TimeEntry) {
+	pairs := overlappingEntries(entries)
+	if len(pairs) == 0 {
+		return
+	}
+
+	fmt.Println()
+	for _, pair := range pairs {
+		a, b := entries[pair[0]], entries[pair[1]]
+		startA, endA := formatClockRange(a)
+		startB, endB := formatClockRange(b)
+		fmt.Printf("Warning: entries %s (%s-%s) and %s (%s-%s) overlap\n",
+			overlapEntryID(a), startA, endA,
+			overlapEntryID(b), startB, endB)
+	}
+}
+
+// overlapEntryID returns the ID of an entry for overlap warnings, entries waiting for sync have none
+func overlapEntryID(entry harvest.TimeEntry) string {
+	if entry.ID == 0 {
+		return "queued"
+	}
+	return strconv.FormatInt(entry.ID, 10)
+}
+
+// confirmNoOverlap warns if a new entry overlaps entries of the same day and asks whether to
+// create it anyway, returning false if the user cancels
+func confirmNoOverlap(client *harvest.Client, request *harvest.TimeEntry) bool {
+	if _, _, ok := request.ClockRange(); !ok {
+		return true
+	}
+
+	existing, err := client.GetAllTimeEntries(timeEntryParams(request.SpentDate, request.SpentDate))
+	if err != nil {
+		fmt.Printf("Could not check for overlapping entries: %v\n", err)
+		return true
+	}
+
+	// The new entry is compared with the existing ones only
+	entries := append([]harvest.TimeEntry{*request}, existing...)
+	var overlaps []harvest.TimeEntry
+	for _, pair := range overlappingEntries(entries) {
+		if pair[0] == 0 {
+			overlaps = append(overlaps, entries[pair[1]])
+		}
+	}
+	if len(overlaps) == 0 {
+		return true
+	}
+
+	start, end := formatClockRange(*request)
+	fmt.Printf("\nWarning: the new entry (%s-%s) overlaps %d entries on %s:\n", start, end, len(overlaps), request.SpentDate)
+	for _, entry := range overlaps {
+		entryStart, entryEnd := formatClockRange(entry)
+		fmt.Printf("  %d: %s-%s %s | %s - %s\n", entry.ID, entryStart, entryEnd, entry.Project.Name, entry.Task.Name, entry.Notes)
+	}
+
+	confirmPrompt := promptui.Select{
+		Label: "What would you like to do?",
+		Items: []string{"Create time entry anyway", "Cancel"},
+	}
+
+	confirmIndex, _, err := confirmPrompt.Run()
+	if err != nil {
+		log.Fatalf("Prompt failed: %v", err)
+	}
+
+	return confirmIndex == 0
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"harvest-cli/pkg/config"
 	"harvest-cli/pkg/harvest"
+	"harvest-cli/pkg/journal"
+	"harvest-cli/pkg/queue"
 	"log"
 	"strconv"
 	"strings"
@@ -21,6 +23,9 @@ type TimeEntry struct {
 	TaskID    int
 	Time      float64
 	Notes     string
+	Started   string // Wall clock start in Harvest's format (e.g. "8:00am"), none if empty
+	Ended     string // Wall clock end in Harvest's format
+	Repo      string // Repository whose branch name may contain a ticket ID, none if empty
 }
 
 // appConfig holds the application configuration
@@ -29,19 +34,34 @@ var appConfig *config.Config
 // CreateCmd returns the create command
 func CreateCmd() *cobra.Command {
 	var useDefault bool
-	var useDefaultMode bool
+	var useDefaultMode, fromGit, fromPending bool
 	var date, projectName, taskName string
 	var timeValue, taskNotes string
+	var startValue, endValue string
 
 	// Initialize the command
 	cmd := &cobra.Command{
-		Use:   "create",
+		Use:   "create [repository...]",
 		Short: "Create a new time entry",
 		Long: `Create a new time entry with date, project, task, and time.
 Example: h create -d 2023-03-06 -p "Corporate Visions | vPlaybook" --task "Software Development" -t 7.5
 If arguments are not provided, you will be prompted for input.
 
-Use -D flag for default mode, which uses default project and task from config.`,
+Use -D flag for default mode, which uses default project and task from config.
+
+Use --start and --end flags to record the wall clock times of the entry (for timestamp
+timesheets); one of them can be combined with -t instead. Entries overlapping other
+entries of the day ask for confirmation.
+Example: h create -p "Project A" -a "Software Development" --start 09:00 --end 12:30
+
+Use --from-git flag to propose entries from the day's commits in the given repositories
+(or the repositories in repo_mappings), one per mapped project and task, with the commit
+subjects as notes and a duration estimated from the commit times.
+Example: h create --from-git ~/src/api ~/src/web -d 2023-03-06
+
+Use --from-pending flag to propose entries from the commits recorded by the git hooks
+(see "h hooks install"), optionally limited to the given repositories and the -d date.
+Logged commits are removed from the pending work buffer.`,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			// Load configuration
 			var err error
@@ -51,7 +71,20 @@ Use -D flag for default mode, which uses default project and task from config.`,
 			}
 		},
 		Run: func(cmd *cobra.Command, args []string) {
-			entry := TimeEntry{}
+			if fromGit {
+				handleGitMode(args, date, projectName, taskName, timeValue)
+				return
+			}
+			if fromPending {
+				handlePendingMode(args, date, projectName, taskName, timeValue)
+				return
+			}
+			if len(args) > 0 {
+				log.Fatalf("Repository arguments can only be used with --from-git or --from-pending")
+			}
+
+			// The branch checked out in the current directory may name a ticket
+			entry := TimeEntry{Repo: "."}
 
 			// Handle default mode
 			if useDefaultMode {
@@ -59,7 +92,7 @@ Use -D flag for default mode, which uses default project and task from config.`,
 				handleDefaultMode(&entry)
 			} else {
 				// Regular mode - process arguments or prompt for input
-				handleRegularMode(cmd, &entry, useDefault, date, projectName, taskName, timeValue, taskNotes)
+				handleRegularMode(cmd, &entry, useDefault, date, projectName, taskName, timeValue, taskNotes, startValue, endValue)
 			}
 
 			// Create the time entry in Harvest
@@ -74,6 +107,10 @@ Use -D flag for default mode, which uses default project and task from config.`,
 	cmd.Flags().StringVarP(&taskName, "action", "a", "", "Action (Task)")
 	cmd.Flags().StringVarP(&timeValue, "time", "t", "", "Duration in the following format (e.g., HH:MM)")
 	cmd.Flags().StringVarP(&taskNotes, "Notes", "n", "", "Notes")
+	cmd.Flags().StringVar(&startValue, "start", "", "Start time in HH:MM format (e.g., 09:00)")
+	cmd.Flags().StringVar(&endValue, "end", "", "End time in HH:MM format (e.g., 12:30)")
+	cmd.Flags().BoolVar(&fromGit, "from-git", false, "Propose entries from the day's git commits in the given repositories")
+	cmd.Flags().BoolVar(&fromPending, "from-pending", false, "Propose entries from the commits recorded by the git hooks")
 
 	return cmd
 }
@@ -137,9 +174,7 @@ func handleDefaultMode(entry *TimeEntry) {
 }
 
 // handleRegularMode handles the regular mode for time entry creation
-func handleRegularMode(cmd *cobra.Command, entry *TimeEntry, useDefault bool, date, projectName, taskName, timeValue, taskNotes string) {
-	var selectedProject *config.Project
-
+func handleRegularMode(cmd *cobra.Command, entry *TimeEntry, useDefault bool, date, projectName, taskName, timeValue, taskNotes, startValue, endValue string) {
 	// Handle date
 	if date != "" {
 		entry.Date = date
@@ -165,68 +200,29 @@ func handleRegularMode(cmd *cobra.Command, entry *TimeEntry, useDefault bool, da
 	}
 
 	// Handle project selection
-	if projectName != "" {
-		// Find project by name
-		selectedProject = appConfig.GetProjectByName(projectName)
-		if selectedProject == nil {
-			fmt.Printf("Project '%s' not found in configuration\n", projectName)
-			return
-		}
-		entry.ProjectID = selectedProject.ID
-	} else {
-		// Create a list of project names for selection
-		projectNames := make([]string, len(appConfig.Projects))
-		for i, project := range appConfig.Projects {
-			projectNames[i] = project.Name
-		}
-
-		prompt := promptui.Select{
-			Label: "Select Project",
-			Items: projectNames,
-		}
-		index, result, err := prompt.Run()
-		if err != nil {
-			fmt.Printf("Prompt failed: %v\n", err)
-			return
-		}
-
-		selectedProject = &appConfig.Projects[index]
-		entry.ProjectID = selectedProject.ID
-		projectName = result
+	selectedProject := selectProject(projectName)
+	if selectedProject == nil {
+		return
 	}
+	entry.ProjectID = selectedProject.ID
 
 	// Handle task selection
-	if taskName != "" {
-		// Find task by name within the selected project
-		task := selectedProject.GetTaskByName(taskName)
-		if task == nil {
-			fmt.Printf("Task '%s' not found in project '%s'\n", taskName, projectName)
-			return
-		}
-		entry.TaskID = task.ID
-	} else {
-		// Create a list of task names for selection
-		var taskNames []string
-		for _, task := range selectedProject.Tasks {
-			taskNames = append(taskNames, task.Name)
-		}
-
-		prompt := promptui.Select{
-			Label: "Select Task",
-			Items: taskNames,
-		}
-		index, result, err := prompt.Run()
-		if err != nil {
-			fmt.Printf("Prompt failed: %v\n", err)
-			return
-		}
-
-		entry.TaskID = selectedProject.Tasks[index].ID
-		taskName = result
+	selectedTask := selectTask(selectedProject, taskName)
+	if selectedTask == nil {
+		return
 	}
+	entry.TaskID = selectedTask.ID
 
 	// Handle time
-	if timeValue != "" {
+	if startValue != "" || endValue != "" {
+		start, end, err := resolveClockRange(startValue, endValue, timeValue)
+		if err != nil {
+			log.Fatalf("Invalid start or end time: %v", err)
+		}
+		entry.Started = harvest.FormatClockTime(start)
+		entry.Ended = harvest.FormatClockTime(end)
+		entry.Time = clockHours(start, end)
+	} else if timeValue != "" {
 		var err error
 		entry.Time, err = parseDuration(timeValue)
 		if err != nil {
@@ -279,13 +275,77 @@ func handleRegularMode(cmd *cobra.Command, entry *TimeEntry, useDefault bool, da
 	fmt.Printf("Project ID: %d\n", entry.ProjectID)
 	fmt.Printf("Task ID: %d\n", entry.TaskID)
 	fmt.Printf("Time: %.2f hours (%02d:%02d)\n", entry.Time, hours, minutes)
-	fmt.Print("Task Notes: \n", entry.Notes)
+	if entry.Started != "" {
+		fmt.Printf("Start: %s\n", entry.Started)
+		fmt.Printf("End: %s\n", entry.Ended)
+	}
+	fmt.Printf("Task Notes: %s\n", entry.Notes)
+}
+
+// selectProject finds a configured project by name or prompts for one, returning nil on failure
+func selectProject(projectName string) *config.Project {
+	if projectName != "" {
+		// Find project by name
+		project := appConfig.GetProjectByName(projectName)
+		if project == nil {
+			fmt.Printf("Project '%s' not found in configuration\n", projectName)
+		}
+		return project
+	}
+
+	// Create a list of project names for selection
+	projectNames := make([]string, len(appConfig.Projects))
+	for i, project := range appConfig.Projects {
+		projectNames[i] = project.Name
+	}
+
+	prompt := promptui.Select{
+		Label: "Select Project",
+		Items: projectNames,
+	}
+	index, _, err := prompt.Run()
+	if err != nil {
+		fmt.Printf("Prompt failed: %v\n", err)
+		return nil
+	}
+
+	return &appConfig.Projects[index]
 }
 
-// createHarvestTimeEntry creates a time entry in Harvest
-func createHarvestTimeEntry(entry *TimeEntry) {
+// selectTask finds a task of the project by name or prompts for one, returning nil on failure
+func selectTask(project *config.Project, taskName string) *config.Task {
+	if taskName != "" {
+		// Find task by name within the selected project
+		task := project.GetTaskByName(taskName)
+		if task == nil {
+			fmt.Printf("Task '%s' not found in project '%s'\n", taskName, project.Name)
+		}
+		return task
+	}
+
+	// Create a list of task names for selection
+	var taskNames []string
+	for _, task := range project.Tasks {
+		taskNames = append(taskNames, task.Name)
+	}
+
+	prompt := promptui.Select{
+		Label: "Select Task",
+		Items: taskNames,
+	}
+	index, _, err := prompt.Run()
+	if err != nil {
+		fmt.Printf("Prompt failed: %v\n", err)
+		return nil
+	}
+
+	return &project.Tasks[index]
+}
+
+// createHarvestTimeEntry creates a time entry in Harvest, returning false if it was cancelled
+func createHarvestTimeEntry(entry *TimeEntry) bool {
 	// Create Harvest API client
-	client := harvest.NewClient(&appConfig.HarvestAPI)
+	client := newHarvestClient()
 
 	// Create time entry request
 	timeEntry := &harvest.TimeEntry{
@@ -294,14 +354,43 @@ func createHarvestTimeEntry(entry *TimeEntry) {
 		TaskID:    entry.TaskID,
 		Hours:     entry.Time,
 		Notes:     entry.Notes,
+
+		StartedTime: entry.Started,
+		EndedTime:   entry.Ended,
+	}
+
+	// Link the entry to the ticket in the notes or branch name
+	applyTicket(timeEntry, entry.Repo)
+
+	// Start and end times are only kept by accounts with timestamp timesheets
+	if timeEntry.StartedTime != "" {
+		dropClockTimes(client, timeEntry)
+	}
+
+	// Warn before exceeding the project budget
+	if !confirmProjectBudget(client, entry.ProjectID, entry.Time) {
+		fmt.Println("Time entry creation cancelled")
+		return false
+	}
+
+	// Warn before overlapping other entries of the day, unless Harvest is already known to be
+	// unreachable and the entry will be queued
+	if !client.Offline() && !confirmNoOverlap(client, timeEntry) {
+		fmt.Println("Time entry creation cancelled")
+		return false
 	}
 
 	// Send request to Harvest API
 	fmt.Println("\nSending time entry to Harvest...")
 	createdEntry, err := client.CreateTimeEntry(timeEntry)
+	if harvest.IsNetworkError(err) {
+		queueOperation(queue.ActionCreate, 0, timeEntry, nil, err)
+		return true
+	}
 	if err != nil {
 		log.Fatalf("Failed to create time entry: %v", err)
 	}
+	recordOperation(journal.ActionCreate, nil, createdEntry)
 
 	// Output success message
 	fmt.Println("\nTime Entry Created Successfully in Harvest!")
@@ -310,7 +399,31 @@ func createHarvestTimeEntry(entry *TimeEntry) {
 	fmt.Printf("Project ID: %d\n", createdEntry.ProjectID)
 	fmt.Printf("Task ID: %d\n", createdEntry.TaskID)
 	fmt.Printf("Hours: %.2f\n", createdEntry.Hours)
+	if createdEntry.StartedTime != "" {
+		fmt.Printf("Start: %s\n", createdEntry.StartedTime)
+		fmt.Printf("End: %s\n", createdEntry.EndedTime)
+	}
 	fmt.Printf("Notes: %s\n", createdEntry.Notes)
+
+	return true
+}
+
+// dropClockTimes removes the start and end times of a new entry when the account tracks durations.
+// If the account can't be checked, the times are kept.
+func dropClockTimes(client *harvest.Client, timeEntry *harvest.TimeEntry) {
+	company, err := client.GetCompany()
+	if err != nil {
+		fmt.Printf("Could not check the timesheet type: %v\n", err)
+		return
+	}
+	if company.WantsTimestampTimers {
+		return
+	}
+
+	fmt.Printf("Note: %s tracks durations, only the duration of %s to %s is sent\n",
+		company.Name, timeEntry.StartedTime, timeEntry.EndedTime)
+	timeEntry.StartedTime = ""
+	timeEntry.EndedTime = ""
 }
 
 // convertDecimalToHoursMinutes converts decimal hours to hours and minutes
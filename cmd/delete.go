@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"harvest-cli/pkg/config"
 	"harvest-cli/pkg/harvest"
+	"harvest-cli/pkg/journal"
+	"harvest-cli/pkg/queue"
 	"log"
 	"os"
 	"strconv"
@@ -17,8 +19,10 @@ import (
 
 // DeleteCmd returns the delete command
 func DeleteCmd() *cobra.Command {
-	var nonInteractive bool
-	var date string
+	var nonInteractive, yes, all bool
+	var date, from, to, project, task, notesMatch, restore string
+	var workers int
+	var rate float64
 
 	cmd := &cobra.Command{
 		Use:   "delete [timeEntryID]",
@@ -27,7 +31,14 @@ func DeleteCmd() *cobra.Command {
 Example: h delete 123456789
 
 By default, uses interactive mode to select time entries to delete.
-Use --non-interactive flag with a time entry ID to delete directly.`,
+Use --non-interactive flag with a time entry ID to delete directly.
+
+Use --from and --to flags with --project, --task and --notes-match to delete every matching
+entry in a date range, after a summary of the entries and hours. The entries are saved to a
+restore file in the data directory before they are deleted; use --restore flag with that file
+to recreate them. At least one filter is required, use --all flag to delete every entry in the
+range instead. Use -y flag to delete without asking for confirmation.
+Example: h delete --from 2024-03-01 --to 2024-03-31 --project "Project A" --notes-match "^test"`,
 		Args: cobra.MaximumNArgs(1),
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			// Load configuration
@@ -39,7 +50,53 @@ Use --non-interactive flag with a time entry ID to delete directly.`,
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			// Create Harvest API client
-			client := harvest.NewClient(&appConfig.HarvestAPI)
+			client := newHarvestClient()
+
+			if restore != "" {
+				handleRestore(client, restore)
+				return
+			}
+
+			if from != "" || to != "" || project != "" || task != "" || notesMatch != "" || all {
+				if len(args) > 0 {
+					log.Fatalf("A time entry ID can't be combined with --from, --to, --project, --task, --notes-match or --all")
+				}
+
+				// Delete by filter, a single day unless a range is given
+				opts := bulkDeleteOptions{From: from, To: to, Workers: workers, Rate: rate, Yes: yes}
+				if opts.From == "" {
+					opts.From = date
+				}
+				if opts.To == "" {
+					opts.To = opts.From
+				}
+				for _, value := range []string{opts.From, opts.To} {
+					if _, err := time.Parse("2006-01-02", value); err != nil {
+						log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+					}
+				}
+				if opts.To < opts.From {
+					log.Fatalf("The to date must not be before the from date")
+				}
+
+				opts.Filter = entryFilter{Project: project, Task: task}
+				if notesMatch != "" {
+					notes, err := compileNotesPattern(notesMatch)
+					if err != nil {
+						log.Fatalf("Invalid --notes-match: %v", err)
+					}
+					opts.Filter.Notes = notes
+				}
+				if opts.Filter.IsEmpty() && !all {
+					log.Fatalf("Use --project, --task or --notes-match to select the entries to delete, or --all to delete every entry from %s to %s", opts.From, opts.To)
+				}
+				if !opts.Filter.IsEmpty() && all {
+					log.Fatalf("--all can't be combined with --project, --task or --notes-match")
+				}
+
+				handleBulkDelete(client, opts)
+				return
+			}
 
 			if len(args) > 0 && nonInteractive {
 				// Direct delete by ID
@@ -95,6 +152,16 @@ Use --non-interactive flag with a time entry ID to delete directly.`,
 	// Define flags
 	cmd.Flags().BoolVarP(&nonInteractive, "non-interactive", "n", false, "Use non-interactive mode with a time entry ID")
 	cmd.Flags().StringVarP(&date, "date", "d", time.Now().Format("2006-01-02"), "Date in YYYY-MM-DD format (default: today)")
+	cmd.Flags().StringVar(&from, "from", "", "Delete matching entries from this date in YYYY-MM-DD format (default: --date)")
+	cmd.Flags().StringVar(&to, "to", "", "Delete matching entries up to this date in YYYY-MM-DD format (default: --from)")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Delete only entries of this project (name or ID)")
+	cmd.Flags().StringVarP(&task, "task", "a", "", "Delete only entries of this task (name or ID)")
+	cmd.Flags().StringVar(&notesMatch, "notes-match", "", "Delete only entries whose notes match this case-insensitive regular expression")
+	cmd.Flags().BoolVar(&all, "all", false, "Delete every entry from --from to --to, without filters")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Delete matching entries without asking for confirmation")
+	cmd.Flags().IntVarP(&workers, "workers", "w", 4, "Number of deletes sent at the same time")
+	cmd.Flags().Float64Var(&rate, "rate", 5, "Maximum number of deletes sent per second")
+	cmd.Flags().StringVar(&restore, "restore", "", "Recreate the entries saved in a restore file")
 
 	return cmd
 }
@@ -118,82 +185,40 @@ func handleInteractiveDelete(client *harvest.Client, date string) {
 		return
 	}
 
-	// Create a custom multi-select interface
 	reader := bufio.NewReader(os.Stdin)
 
-	// Track selected entries
-	selectedIndices := make(map[int]bool)
-
-	// Display instructions
-	fmt.Println("\nMulti-select Time Entries to Delete:")
-	fmt.Println("-----------------------------------")
-	fmt.Println("Instructions:")
-	fmt.Println("- Enter the number of an entry to select/deselect it")
-	fmt.Println("- Enter 'a' to select all entries")
-	fmt.Println("- Enter 'n' to deselect all entries")
-	fmt.Println("- Enter 'd' when done to proceed with deletion")
-	fmt.Println("- Enter 'q' to quit without deleting")
-	fmt.Println("-----------------------------------")
-
-	// Main selection loop
-	for {
-		// Display the current list with selection status
-		fmt.Println("\nTime entries for", date, ":")
-		fmt.Println("-----------------------------------")
-
-		for i, entry := range timeEntries {
-			hours, minutes := convertDecimalToHoursMinutes(entry.Hours)
-			selected := " "
-			if selectedIndices[i] {
-				selected = "X"
-			}
-
-			fmt.Printf("[%d] [%s] %s - %s (%02d:%02d) - %s\n",
-				i+1,
-				selected,
-				entry.Project.Name,
-				entry.Task.Name,
-				hours,
-				minutes,
-				entry.Notes)
-		}
-
-		fmt.Println("-----------------------------------")
-		fmt.Printf("%d of %d entries selected\n", len(selectedIndices), len(timeEntries))
-		fmt.Print("Enter selection (number, a, n, d, q): ")
+	// Build the checklist of entries
+	var labels []string
+	for _, entry := range timeEntries {
+		hours, minutes := convertDecimalToHoursMinutes(entry.Hours)
+		labels = append(labels, fmt.Sprintf("%s - %s (%02d:%02d) - %s",
+			entry.Project.Name,
+			entry.Task.Name,
+			hours,
+			minutes,
+			entry.Notes))
+	}
 
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(input)
+	checklist := MultiSelect{
+		Title:  fmt.Sprintf("Time entries for %s", date),
+		Action: "deletion",
+		Items:  labels,
+		Disabled: func(index int) error {
+			// Locked entries cannot be selected
+			return checkEntryUnlocked(&timeEntries[index], "deleted")
+		},
+		LockedNote: "Entries marked with [L] are locked and cannot be deleted",
+	}
 
-		switch strings.ToLower(input) {
-		case "a": // Select all
-			for i := range timeEntries {
-				selectedIndices[i] = true
-			}
-		case "n": // Deselect all
-			selectedIndices = make(map[int]bool)
-		case "d": // Done, proceed with deletion
-			goto processSelection
-		case "q": // Quit
-			fmt.Println("Operation cancelled")
-			return
-		default: // Try to parse as a number
-			num, err := strconv.Atoi(input)
-			if err == nil && num > 0 && num <= len(timeEntries) {
-				// Toggle selection
-				idx := num - 1
-				selectedIndices[idx] = !selectedIndices[idx]
-			}
-		}
+	indices, ok := checklist.Run(reader)
+	if !ok {
+		return
 	}
 
-processSelection:
 	// Collect selected entries
 	var selectedEntries []harvest.TimeEntry
-	for i, entry := range timeEntries {
-		if selectedIndices[i] {
-			selectedEntries = append(selectedEntries, entry)
-		}
+	for _, index := range indices {
+		selectedEntries = append(selectedEntries, timeEntries[index])
 	}
 
 	if len(selectedEntries) == 0 {
@@ -228,14 +253,19 @@ processSelection:
 	}
 
 	// Delete the selected time entries
-	var successCount, failCount int
-	for _, entry := range selectedEntries {
+	var successCount, failCount, queuedCount int
+	for i, entry := range selectedEntries {
 		err = client.DeleteTimeEntry(entry.ID)
-		if err != nil {
+		if harvest.IsNetworkError(err) {
+			// Keep the delete for "h sync"
+			queueOperation(queue.ActionDelete, entry.ID, nil, &selectedEntries[i], err)
+			queuedCount++
+		} else if err != nil {
 			fmt.Printf("Failed to delete time entry %d: %v\n", entry.ID, err)
 			failCount++
 		} else {
 			fmt.Printf("Time entry %d deleted successfully\n", entry.ID)
+			recordOperation(journal.ActionDelete, &selectedEntries[i], nil)
 			successCount++
 		}
 	}
@@ -246,6 +276,9 @@ processSelection:
 	fmt.Printf("Total: %d entries\n", len(selectedEntries))
 	fmt.Printf("Successful: %d\n", successCount)
 	fmt.Printf("Failed: %d\n", failCount)
+	if queuedCount > 0 {
+		fmt.Printf("Queued for sync: %d\n", queuedCount)
+	}
 	fmt.Println("-----------------------------------")
 }
 
@@ -253,6 +286,10 @@ processSelection:
 func handleDirectDelete(client *harvest.Client, id int64) {
 	// Get the time entry to confirm details
 	entry, err := client.GetTimeEntry(id)
+	if harvest.IsNetworkError(err) {
+		handleOfflineDelete(id, err)
+		return
+	}
 	if err != nil {
 		log.Fatalf("Failed to get time entry: %v", err)
 	}
@@ -269,6 +306,11 @@ func handleDirectDelete(client *harvest.Client, id int64) {
 		fmt.Printf("Notes: %s\n", entry.Notes)
 	}
 
+	// Locked entries cannot be deleted
+	if err := checkEntryUnlocked(entry, "deleted"); err != nil {
+		log.Fatal(err)
+	}
+
 	// Confirm deletion with options
 	deleteOptions := []string{"Delete this time entry", "Cancel deletion"}
 	deletePrompt := promptui.Select{
@@ -288,9 +330,26 @@ func handleDirectDelete(client *harvest.Client, id int64) {
 
 	// Delete the time entry
 	err = client.DeleteTimeEntry(id)
+	if harvest.IsNetworkError(err) {
+		queueOperation(queue.ActionDelete, id, nil, entry, err)
+		return
+	}
 	if err != nil {
 		log.Fatalf("Failed to delete time entry: %v", err)
 	}
+	recordOperation(journal.ActionDelete, entry, nil)
 
 	fmt.Printf("Time entry %d deleted successfully\n", id)
 }
+
+// handleOfflineDelete queues the deletion of a time entry that can't be fetched while Harvest is unreachable
+func handleOfflineDelete(id int64, cause error) {
+	fmt.Printf("Harvest is unreachable, the details of time entry %d can't be shown\n", id)
+
+	if !confirmSelect("Queue the deletion for the next sync", "Cancel deletion") {
+		fmt.Println("Deletion cancelled")
+		return
+	}
+
+	queueOperation(queue.ActionDelete, id, nil, nil, cause)
+}
@@ -0,0 +1,444 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// ExpenseCmd returns the expense command
+func ExpenseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "expense",
+		Short: "Log, list and delete expenses",
+		Long: `Log, list and delete expenses in Harvest.
+Example: h expense create -p "Project A" -c Travel --cost 23.50 -n "Taxi" -r receipt.pdf`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// If no subcommand is provided, print help
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(expenseCreateCmd())
+	cmd.AddCommand(expenseListCmd())
+	cmd.AddCommand(expenseDeleteCmd())
+
+	return cmd
+}
+
+// expenseCreateCmd returns the expense create subcommand
+func expenseCreateCmd() *cobra.Command {
+	var date, projectName, categoryName, notes, receipt string
+	var cost, units float64
+	var billable bool
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Log a new expense",
+		Long: `Log a new expense with date, project, category and cost.
+Example: h expense create -d 2024-03-04 -p "Project A" -c Travel --cost 23.50 -n "Taxi"
+If arguments are not provided, you will be prompted for input.
+
+Use --units instead of --cost for unit based categories such as mileage.
+Use -r flag to attach a receipt file (image or PDF).`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			expense := &harvest.ExpenseRequest{SpentDate: date}
+
+			// Handle date
+			if expense.SpentDate == "" {
+				prompt := promptui.Prompt{
+					Label:     "Date (YYYY-MM-DD)",
+					Default:   time.Now().Format("2006-01-02"),
+					AllowEdit: true,
+					Validate: func(input string) error {
+						_, err := time.Parse("2006-01-02", input)
+						return err
+					},
+				}
+				result, err := prompt.Run()
+				if err != nil {
+					log.Fatalf("Prompt failed: %v", err)
+				}
+				expense.SpentDate = result
+			} else if _, err := time.Parse("2006-01-02", expense.SpentDate); err != nil {
+				log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+			}
+
+			// Handle project selection
+			project := selectProject(projectName)
+			if project == nil {
+				return
+			}
+			expense.ProjectID = int64(project.ID)
+
+			// Handle category selection
+			category := selectExpenseCategory(client, categoryName)
+			expense.ExpenseCategoryID = category.ID
+
+			// Handle cost or units, depending on the category
+			if category.IsUnitBased() {
+				if units <= 0 {
+					units = promptAmount(fmt.Sprintf("Units (%s)", category.UnitName))
+				}
+				expense.Units = &units
+			} else {
+				if cost <= 0 {
+					cost = promptAmount("Total Cost")
+				}
+				expense.TotalCost = &cost
+			}
+
+			// Handle notes
+			if notes == "" {
+				prompt := promptui.Prompt{
+					Label: "Notes (optional)",
+				}
+				result, err := prompt.Run()
+				if err != nil {
+					log.Fatalf("Prompt failed: %v", err)
+				}
+				notes = result
+			}
+			expense.Notes = notes
+
+			if cmd.Flags().Changed("billable") {
+				expense.Billable = &billable
+			}
+
+			if receipt != "" {
+				if _, err := os.Stat(receipt); err != nil {
+					log.Fatalf("Failed to read receipt: %v", err)
+				}
+			}
+
+			// Send request to Harvest API
+			fmt.Println("\nSending expense to Harvest...")
+			created, err := client.CreateExpense(expense, receipt)
+			if err != nil {
+				log.Fatalf("Failed to create expense: %v", err)
+			}
+
+			// Output success message
+			fmt.Println("\nExpense Created Successfully in Harvest!")
+			fmt.Printf("Expense ID: %d\n", created.ID)
+			fmt.Printf("Date: %s\n", created.SpentDate)
+			fmt.Printf("Project: %s\n", created.Project.Name)
+			fmt.Printf("Category: %s\n", created.ExpenseCategory.Name)
+			fmt.Printf("Total Cost: %s\n", formatAmount(created.TotalCost))
+			if created.Notes != "" {
+				fmt.Printf("Notes: %s\n", created.Notes)
+			}
+			if created.Receipt != nil {
+				fmt.Printf("Receipt: %s\n", created.Receipt.FileName)
+			}
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringVarP(&date, "date", "d", "", "Date in YYYY-MM-DD format (default: today)")
+	cmd.Flags().StringVarP(&projectName, "project", "p", "", "Project")
+	cmd.Flags().StringVarP(&categoryName, "category", "c", "", "Expense category")
+	cmd.Flags().Float64Var(&cost, "cost", 0, "Total cost")
+	cmd.Flags().Float64Var(&units, "units", 0, "Units for unit based categories (e.g., kilometers)")
+	cmd.Flags().StringVarP(&notes, "notes", "n", "", "Notes")
+	cmd.Flags().StringVarP(&receipt, "receipt", "r", "", "Path to a receipt file to attach")
+	cmd.Flags().BoolVar(&billable, "billable", false, "Mark the expense as billable (default: the project setting)")
+
+	return cmd
+}
+
+// expenseListCmd returns the expense list subcommand
+func expenseListCmd() *cobra.Command {
+	var from, to string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List expenses",
+		Long: `List expenses in a date range with totals per category.
+By default, lists the expenses of the current month.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			// Default to the current month
+			now := time.Now()
+			if from == "" {
+				from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).Format("2006-01-02")
+			}
+			if to == "" {
+				to = time.Date(now.Year(), now.Month()+1, 0, 0, 0, 0, 0, time.Local).Format("2006-01-02")
+			}
+			for _, value := range []string{from, to} {
+				if _, err := time.Parse("2006-01-02", value); err != nil {
+					log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+				}
+			}
+
+			handleExpenseList(client, from, to)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringVar(&from, "from", "", "Start date in YYYY-MM-DD format (default: first day of the current month)")
+	cmd.Flags().StringVar(&to, "to", "", "End date in YYYY-MM-DD format (default: last day of the current month)")
+
+	return cmd
+}
+
+// expenseDeleteCmd returns the expense delete subcommand
+func expenseDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <expenseID>",
+		Short: "Delete an expense",
+		Long: `Delete an expense by ID.
+Example: h expense delete 123456789`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				log.Fatalf("Invalid expense ID: %v", err)
+			}
+
+			handleExpenseDelete(client, id)
+		},
+	}
+
+	return cmd
+}
+
+// handleExpenseList lists the expenses between two dates
+func handleExpenseList(client *harvest.Client, from, to string) {
+	fmt.Printf("Fetching expenses from %s to %s...\n", from, to)
+	expenses, err := client.ListExpenses(map[string]string{"from": from, "to": to})
+	if err != nil {
+		log.Fatalf("Failed to get expenses: %v", err)
+	}
+
+	if len(expenses) == 0 {
+		fmt.Printf("No expenses found from %s to %s\n", from, to)
+		return
+	}
+
+	sort.Slice(expenses, func(i, j int) bool {
+		return expenses[i].SpentDate < expenses[j].SpentDate
+	})
+
+	fmt.Printf("\nExpenses from %s to %s:\n", from, to)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDate\tProject\tCategory\tNotes\tAmount\tReceipt\tStatus")
+	fmt.Fprintln(w, "----\t----\t-------\t--------\t-----\t------\t-------\t------")
+
+	for _, expense := range expenses {
+		// Truncate notes if too long
+		notes := expense.Notes
+		if len(notes) > 30 {
+			notes = notes[:27] + "..."
+		}
+
+		receipt := "-"
+		if expense.Receipt != nil {
+			receipt = expense.Receipt.FileName
+		}
+
+		status := expense.ApprovalStatus
+		if status == "" || status == "unsubmitted" {
+			status = "-"
+		}
+		if expense.IsLocked {
+			status += " (locked)"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			expense.ID,
+			expense.SpentDate,
+			expense.Project.Name,
+			expense.ExpenseCategory.Name,
+			notes,
+			formatAmount(expense.TotalCost),
+			receipt,
+			status)
+	}
+
+	w.Flush()
+
+	displayExpenseTotals(expenses)
+}
+
+// displayExpenseTotals prints the expense totals per category
+func displayExpenseTotals(expenses []harvest.Expense) {
+	var total, billable float64
+	categoryTotals := make(map[string]float64)
+	for _, expense := range expenses {
+		total += expense.TotalCost
+		categoryTotals[expense.ExpenseCategory.Name] += expense.TotalCost
+		if expense.Billable {
+			billable += expense.TotalCost
+		}
+	}
+
+	var categories []string
+	for category := range categoryTotals {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	fmt.Println("\nExpenses by Category:")
+	fmt.Println("---------------------")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Category\tAmount\t% of Total")
+	fmt.Fprintln(w, "--------\t------\t----------")
+
+	for _, category := range categories {
+		fmt.Fprintf(w, "%s\t%s\t%s\n",
+			category,
+			formatAmount(categoryTotals[category]),
+			formatShare(categoryTotals[category], total))
+	}
+
+	fmt.Fprintf(w, "TOTAL\t%s\t%s\n", formatAmount(total), formatShare(total, total))
+	fmt.Fprintf(w, "BILLABLE\t%s\t%s\n", formatAmount(billable), formatShare(billable, total))
+
+	w.Flush()
+}
+
+// formatShare formats an amount as a percentage of the total, or "-" if the total is zero
+func formatShare(amount, total float64) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", (amount/total)*100)
+}
+
+// handleExpenseDelete deletes an expense after confirmation
+func handleExpenseDelete(client *harvest.Client, id int64) {
+	expense, err := client.GetExpense(id)
+	if err != nil {
+		log.Fatalf("Failed to get expense: %v", err)
+	}
+
+	// Display expense details
+	fmt.Println("\nExpense Details:")
+	fmt.Printf("ID: %d\n", expense.ID)
+	fmt.Printf("Date: %s\n", expense.SpentDate)
+	fmt.Printf("Project: %s\n", expense.Project.Name)
+	fmt.Printf("Category: %s\n", expense.ExpenseCategory.Name)
+	fmt.Printf("Total Cost: %s\n", formatAmount(expense.TotalCost))
+	if expense.Notes != "" {
+		fmt.Printf("Notes: %s\n", expense.Notes)
+	}
+
+	// Locked expenses cannot be deleted
+	if expense.IsLocked {
+		reason := expense.LockedReason
+		if reason == "" {
+			reason = "the expense has been submitted or approved"
+		}
+		log.Fatalf("Expense %d is locked and cannot be deleted: %s", expense.ID, reason)
+	}
+
+	// Confirm deletion with options
+	deletePrompt := promptui.Select{
+		Label: "What would you like to do?",
+		Items: []string{"Delete this expense", "Cancel deletion"},
+	}
+
+	deleteIndex, _, err := deletePrompt.Run()
+	if err != nil {
+		log.Fatalf("Prompt failed: %v", err)
+	}
+
+	if deleteIndex == 1 {
+		fmt.Println("Deletion cancelled")
+		return
+	}
+
+	if err := client.DeleteExpense(id); err != nil {
+		log.Fatalf("Failed to delete expense: %v", err)
+	}
+
+	fmt.Printf("Expense %d deleted successfully\n", id)
+}
+
+// selectExpenseCategory finds an active expense category by name or prompts for one
+func selectExpenseCategory(client *harvest.Client, categoryName string) *harvest.ExpenseCategory {
+	categories, err := client.ListExpenseCategories(map[string]string{"is_active": "true"})
+	if err != nil {
+		log.Fatalf("Failed to get expense categories: %v", err)
+	}
+	if len(categories) == 0 {
+		log.Fatalf("No active expense categories found in Harvest")
+	}
+
+	if categoryName != "" {
+		for i, category := range categories {
+			if strings.EqualFold(category.Name, categoryName) {
+				return &categories[i]
+			}
+		}
+		log.Fatalf("Expense category '%s' not found", categoryName)
+	}
+
+	categoryNames := make([]string, len(categories))
+	for i, category := range categories {
+		categoryNames[i] = category.Name
+	}
+
+	prompt := promptui.Select{
+		Label: "Select Category",
+		Items: categoryNames,
+	}
+	index, _, err := prompt.Run()
+	if err != nil {
+		log.Fatalf("Prompt failed: %v", err)
+	}
+
+	return &categories[index]
+}
+
+// promptAmount prompts for a positive decimal amount
+func promptAmount(label string) float64 {
+	prompt := promptui.Prompt{
+		Label: label,
+		Validate: func(input string) error {
+			value, err := strconv.ParseFloat(input, 64)
+			if err != nil || value <= 0 {
+				return errors.New("please enter a positive number")
+			}
+			return nil
+		},
+	}
+	result, err := prompt.Run()
+	if err != nil {
+		log.Fatalf("Prompt failed: %v", err)
+	}
+
+	value, _ := strconv.ParseFloat(result, 64)
+	return value
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/harvest"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// entryFilter selects time entries by project, task and notes, empty fields match any entry
+type entryFilter struct {
+	Project string         // Project name or ID in Harvest
+	Task    string         // Task name or ID in Harvest
+	Notes   *regexp.Regexp // Case-insensitive pattern of the notes
+}
+
+// Matches reports whether a time entry passes the filter
+func (f entryFilter) Matches(entry harvest.TimeEntry) bool {
+	if f.Project != "" && !matchesNameOrID(f.Project, entry.Project.Name, entry.Project.ID) {
+		return false
+	}
+	if f.Task != "" && !matchesNameOrID(f.Task, entry.Task.Name, entry.Task.ID) {
+		return false
+	}
+	if f.Notes != nil && !f.Notes.MatchString(entry.Notes) {
+		return false
+	}
+	return true
+}
+
+// IsEmpty reports whether the filter matches every entry
+func (f entryFilter) IsEmpty() bool {
+	return f.Project == "" && f.Task == "" && f.Notes == nil
+}
+
+// compileNotesPattern compiles a case-insensitive notes pattern
+func compileNotesPattern(pattern string) (*regexp.Regexp, error) {
+	notes, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notes pattern %q: %w", pattern, err)
+	}
+	return notes, nil
+}
+
+// matchesNameOrID reports whether a filter value is the name (case-insensitive) or ID of a project or task
+func matchesNameOrID(value, name string, id int64) bool {
+	return strings.EqualFold(value, name) || value == strconv.FormatInt(id, 10)
+}
+
+// parseAssignments parses "key=value" pairs separated by commas, such as "project=Project A,task=Meetings"
+func parseAssignments(value string, allowed ...string) (map[string]string, error) {
+	assignments := make(map[string]string)
+
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		key, val, found := strings.Cut(part, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		val = strings.TrimSpace(val)
+		if !found || val == "" {
+			return nil, fmt.Errorf("invalid %q, expected key=value", part)
+		}
+
+		known := false
+		for _, name := range allowed {
+			if key == name {
+				known = true
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown key %q, expected one of: %s", key, strings.Join(allowed, ", "))
+		}
+
+		assignments[key] = val
+	}
+
+	return assignments, nil
+}
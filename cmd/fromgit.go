@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/gitlog"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/manifoldco/promptui"
+)
+
+// gitEntryGroup holds the commits of a day in the repositories mapped to the same project and task
+type gitEntryGroup struct {
+	Date    string
+	Project *config.Project
+	Task    *config.Task
+	Root    string                     // Directory of the first repository
+	Repos   []string                   // Repository names in the order they were given
+	Commits map[string][]gitlog.Commit // Commits by repository name, oldest first
+}
+
+// handleGitMode creates time entries from the commits of a day, one per project and task
+func handleGitMode(repos []string, date, projectName, taskName, timeValue string) {
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+	}
+
+	// Default to the mapped repositories
+	if len(repos) == 0 {
+		for _, mapping := range appConfig.RepoMappings {
+			repos = append(repos, mapping.Path)
+		}
+	}
+	if len(repos) == 0 {
+		log.Fatalf("No repositories given. Pass repository paths or set repo_mappings in config.json")
+	}
+
+	var roots []string
+	commitsByRoot := make(map[string][]gitlog.Commit)
+	for _, repo := range repos {
+		path, err := config.ExpandPath(repo)
+		if err != nil {
+			log.Fatalf("Invalid repository path %s: %v", repo, err)
+		}
+		root, err := gitlog.TopLevel(path)
+		if err != nil {
+			fmt.Printf("Skipping %s: %v\n", repo, err)
+			continue
+		}
+
+		author := gitAuthor(root)
+		commits, err := gitlog.Commits(root, author, day, day.AddDate(0, 0, 1))
+		if err != nil {
+			fmt.Printf("Skipping %s: %v\n", repo, err)
+			continue
+		}
+		if len(commits) == 0 {
+			fmt.Printf("No commits by %s in %s on %s\n", author, root, date)
+			continue
+		}
+
+		roots = append(roots, root)
+		commitsByRoot[root] = commits
+	}
+
+	if len(roots) == 0 {
+		fmt.Printf("No commits found on %s\n", date)
+		return
+	}
+
+	groups := groupGitCommits(roots, commitsByRoot, projectName, taskName)
+	if groups == nil {
+		return
+	}
+	if timeValue != "" && len(groups) > 1 {
+		log.Fatalf("The -t flag can only be used when all commits belong to the same project and task")
+	}
+
+	for _, group := range groups {
+		createGitEntry(group, timeValue)
+	}
+}
+
+// groupGitCommits groups the commits of repositories by day and by the project and task
+// of each repository, returning nil if no project or task was selected
+func groupGitCommits(roots []string, commitsByRoot map[string][]gitlog.Commit, projectName, taskName string) []*gitEntryGroup {
+	var groups []*gitEntryGroup
+	for _, root := range roots {
+		project, task := selectRepoProject(root, projectName, taskName)
+		if project == nil || task == nil {
+			return nil
+		}
+
+		for _, commit := range commitsByRoot[root] {
+			date := commit.Time.Local().Format("2006-01-02")
+
+			// Group repositories mapped to the same project and task
+			var group *gitEntryGroup
+			for _, existing := range groups {
+				if existing.Date == date && existing.Project.ID == project.ID && existing.Task.ID == task.ID {
+					group = existing
+				}
+			}
+			if group == nil {
+				group = &gitEntryGroup{Date: date, Project: project, Task: task, Root: root, Commits: make(map[string][]gitlog.Commit)}
+				groups = append(groups, group)
+			}
+
+			if _, exists := group.Commits[commit.Repo]; !exists {
+				group.Repos = append(group.Repos, commit.Repo)
+			}
+			group.Commits[commit.Repo] = append(group.Commits[commit.Repo], commit)
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Date < groups[j].Date
+	})
+
+	return groups
+}
+
+// createGitEntry proposes a time entry for a group of commits and creates it, returning false if it was cancelled
+func createGitEntry(group *gitEntryGroup, timeValue string) bool {
+	entry := TimeEntry{
+		Date:      group.Date,
+		ProjectID: group.Project.ID,
+		TaskID:    group.Task.ID,
+		Repo:      group.Root,
+	}
+
+	displayGitGroup(group)
+
+	// Propose the estimated duration and the commit subjects
+	if timeValue != "" {
+		var err error
+		entry.Time, err = parseDuration(timeValue)
+		if err != nil {
+			log.Fatalf("Invalid duration format: %v", err)
+		}
+	} else {
+		var commits []gitlog.Commit
+		for _, repo := range group.Repos {
+			commits = append(commits, group.Commits[repo]...)
+		}
+		entry.Time = promptDuration(gitlog.EstimateDuration(commits))
+	}
+	entry.Notes = promptNotes(gitNotes(group))
+
+	return createHarvestTimeEntry(&entry)
+}
+
+// gitAuthor returns the configured commit author, or the git user email of the repository
+func gitAuthor(repo string) string {
+	if appConfig.GitAuthor != "" {
+		return appConfig.GitAuthor
+	}
+
+	email, err := gitlog.UserEmail(repo)
+	if err != nil || email == "" {
+		log.Fatalf("No git author configured. Please set git_author in config.json or user.email in git")
+	}
+	return email
+}
+
+// selectRepoProject returns the project and task of a repository from the flags, the repo
+// mappings or a prompt, returning nil on failure
+func selectRepoProject(repo, projectName, taskName string) (*config.Project, *config.Task) {
+	mapping := appConfig.GetRepoMapping(repo)
+	if projectName == "" && mapping != nil {
+		projectName = mapping.Project
+	}
+	if projectName == "" {
+		fmt.Printf("Repository %s is not mapped to a project\n", repo)
+	}
+
+	project := selectProject(projectName)
+	if project == nil {
+		return nil, nil
+	}
+
+	if taskName == "" && mapping != nil && mapping.Project == project.Name {
+		taskName = mapping.Task
+	}
+	return project, selectTask(project, taskName)
+}
+
+// displayGitGroup prints the commits of a project and task grouped by repository
+func displayGitGroup(group *gitEntryGroup) {
+	fmt.Printf("\nCommits on %s for %s | %s:\n", group.Date, group.Project.Name, group.Task.Name)
+	fmt.Println("-----------------------------------")
+	for _, repo := range group.Repos {
+		fmt.Printf("%s:\n", repo)
+		for _, commit := range group.Commits[repo] {
+			fmt.Printf("  %s %s %s\n", commit.Time.Local().Format("15:04"), commit.Hash[:7], commit.Subject)
+		}
+	}
+	fmt.Println("-----------------------------------")
+}
+
+// gitNotes proposes notes from the commit subjects, grouped by repository
+func gitNotes(group *gitEntryGroup) string {
+	var parts []string
+	for _, repo := range group.Repos {
+		var subjects []string
+		for _, commit := range group.Commits[repo] {
+			if !containsString(subjects, commit.Subject) {
+				subjects = append(subjects, commit.Subject)
+			}
+		}
+
+		if len(group.Repos) == 1 {
+			return strings.Join(subjects, "; ")
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", repo, strings.Join(subjects, "; ")))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// promptDuration prompts for a duration with a proposed value
+func promptDuration(proposed time.Duration) float64 {
+	minutes := int(math.Round(proposed.Minutes()))
+	prompt := promptui.Prompt{
+		Label:     "Time (HH:MM)",
+		Default:   fmt.Sprintf("%02d:%02d", minutes/60, minutes%60),
+		AllowEdit: true,
+		Validate: func(input string) error {
+			_, err := parseDuration(input)
+			return err
+		},
+	}
+
+	result, err := prompt.Run()
+	if err != nil {
+		log.Fatalf("Prompt failed: %v", err)
+	}
+
+	duration, _ := parseDuration(result)
+	return duration
+}
+
+// promptNotes prompts for notes with a proposed value
+func promptNotes(proposed string) string {
+	prompt := promptui.Prompt{
+		Label:     "Task Notes",
+		Default:   proposed,
+		AllowEdit: true,
+		Validate: func(input string) error {
+			if input == "" {
+				return errors.New("notes cannot be blank")
+			}
+
+			return nil
+		},
+	}
+
+	result, err := prompt.Run()
+	if err != nil {
+		log.Fatalf("Prompt failed: %v", err)
+	}
+
+	return result
+}
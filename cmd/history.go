@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"harvest-cli/pkg/journal"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// HistoryCmd returns the history command
+func HistoryCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the journal of time entry changes",
+		Long: `Show the time entries created, updated and deleted with this CLI, most recent first.
+Operations that have been reversed with "h undo" are marked as undone.
+Use -n flag to change the number of operations shown (default: 20, 0 for all).`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			handleHistory(limit)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Number of operations to show, 0 for all")
+
+	return cmd
+}
+
+// handleHistory prints the most recent operations of the current account
+func handleHistory(limit int) {
+	j, err := openJournal()
+	if err != nil {
+		log.Fatalf("Failed to open journal: %v", err)
+	}
+
+	operations, err := j.Operations()
+	if err != nil {
+		log.Fatalf("Failed to read journal: %v", err)
+	}
+
+	// Keep the operations of the current account, most recent first
+	var history []journal.Operation
+	for i := len(operations) - 1; i >= 0; i-- {
+		if operations[i].AccountID == appConfig.HarvestAPI.AccountID {
+			history = append(history, operations[i])
+		}
+	}
+
+	if len(history) == 0 {
+		fmt.Println("No operations recorded yet")
+		return
+	}
+	if limit > 0 && len(history) > limit {
+		history = history[:limit]
+	}
+
+	undone := journal.UndoneIDs(operations)
+
+	fmt.Printf("Journal: %s\n\n", j.Path())
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tTime\tAction\tEntry ID\tDate\tProject | Task\tDuration\tStatus")
+	fmt.Fprintln(w, "-\t----\t------\t--------\t----\t--------------\t--------\t------")
+
+	for _, op := range history {
+		// Show the entry as it was before a delete and after a create or update
+		snapshot := op.After
+		if snapshot == nil {
+			snapshot = op.Before
+		}
+		if snapshot == nil {
+			snapshot = &harvest.TimeEntry{}
+		}
+
+		hours, minutes := convertDecimalToHoursMinutes(snapshot.Hours)
+		duration := fmt.Sprintf("%02d:%02d", hours, minutes)
+		if op.Action == journal.ActionUpdate && op.Before != nil && op.Before.Hours != snapshot.Hours {
+			oldHours, oldMinutes := convertDecimalToHoursMinutes(op.Before.Hours)
+			duration = fmt.Sprintf("%02d:%02d -> %s", oldHours, oldMinutes, duration)
+		}
+
+		status := "-"
+		if undone[op.ID] {
+			status = "undone"
+		} else if op.Undoes != 0 {
+			status = fmt.Sprintf("undo of #%d", op.Undoes)
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%s | %s\t%s\t%s\n",
+			op.ID,
+			op.Time.Local().Format("2006-01-02 15:04"),
+			op.Action,
+			op.EntryID,
+			snapshot.SpentDate,
+			snapshot.Project.Name,
+			snapshot.Task.Name,
+			duration,
+			status)
+	}
+
+	w.Flush()
+}
@@ -0,0 +1,393 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/gitlog"
+	"harvest-cli/pkg/pending"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Markers around the lines added to git hooks
+const (
+	hookBlockStart = "# >>> harvest-cli"
+	hookBlockEnd   = "# <<< harvest-cli"
+)
+
+// supportedHooks lists the git hooks that can record commits
+var supportedHooks = []string{"post-commit", "pre-push"}
+
+// HooksCmd returns the hooks command
+func HooksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Manage git hooks that record commits for time logging",
+		Long: `Install git hooks that record your commits in a pending work buffer in the data directory.
+Use "h create --from-pending" to turn the recorded commits into time entries.
+Example: h hooks install ~/src/project-a-api`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// If no subcommand is provided, print help
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(hooksInstallCmd())
+	cmd.AddCommand(hooksUninstallCmd())
+	cmd.AddCommand(hooksRecordCmd())
+
+	return cmd
+}
+
+// hooksInstallCmd returns the hooks install subcommand
+func hooksInstallCmd() *cobra.Command {
+	var hooks []string
+
+	cmd := &cobra.Command{
+		Use:   "install [repository]",
+		Short: "Install the git hooks in a repository",
+		Long: `Install hooks in a git repository (default: the current directory) that record
+your commits for "h create --from-pending".
+The post-commit hook records each commit, the pre-push hook records the pushed commits.
+Existing hooks are kept, the harvest-cli lines are added at the end.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			root := hookRepository(args)
+			validateHooks(hooks)
+
+			// The hooks call this executable
+			executable, err := os.Executable()
+			if err == nil {
+				executable, err = filepath.EvalSymlinks(executable)
+			}
+			if err != nil {
+				log.Fatalf("Failed to find the h executable: %v", err)
+			}
+
+			hooksDir, err := gitlog.HooksDir(root)
+			if err != nil {
+				log.Fatalf("Failed to find hooks directory: %v", err)
+			}
+			if err := os.MkdirAll(hooksDir, 0755); err != nil {
+				log.Fatalf("Failed to create hooks directory: %v", err)
+			}
+
+			for _, hook := range hooks {
+				block := fmt.Sprintf("%s\n%s hooks record %s || true\n%s\n", hookBlockStart, shellQuote(executable), hook, hookBlockEnd)
+				if err := installHook(filepath.Join(hooksDir, hook), block); err != nil {
+					log.Fatalf("Failed to install %s hook: %v", hook, err)
+				}
+				fmt.Printf("Installed %s hook in %s\n", hook, root)
+			}
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringSliceVar(&hooks, "hook", []string{"post-commit"}, "Hooks to install: post-commit, pre-push")
+
+	return cmd
+}
+
+// hooksUninstallCmd returns the hooks uninstall subcommand
+func hooksUninstallCmd() *cobra.Command {
+	var hooks []string
+
+	cmd := &cobra.Command{
+		Use:   "uninstall [repository]",
+		Short: "Remove the git hooks from a repository",
+		Long:  `Remove the harvest-cli lines from the hooks of a git repository (default: the current directory).`,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			root := hookRepository(args)
+			validateHooks(hooks)
+
+			hooksDir, err := gitlog.HooksDir(root)
+			if err != nil {
+				log.Fatalf("Failed to find hooks directory: %v", err)
+			}
+
+			for _, hook := range hooks {
+				removed, err := uninstallHook(filepath.Join(hooksDir, hook))
+				if err != nil {
+					log.Fatalf("Failed to uninstall %s hook: %v", hook, err)
+				}
+				if removed {
+					fmt.Printf("Removed %s hook from %s\n", hook, root)
+				}
+			}
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringSliceVar(&hooks, "hook", supportedHooks, "Hooks to remove: post-commit, pre-push")
+
+	return cmd
+}
+
+// hooksRecordCmd returns the hooks record subcommand, called by the installed hooks
+func hooksRecordCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "record <post-commit|pre-push>",
+		Short:     "Record commits in the pending work buffer",
+		Hidden:    true,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: supportedHooks,
+		Run: func(cmd *cobra.Command, args []string) {
+			root, err := gitlog.TopLevel(".")
+			if err != nil {
+				log.Fatalf("Failed to find repository: %v", err)
+			}
+			author := "--author=" + gitAuthor(root)
+
+			var commits []gitlog.Commit
+			switch args[0] {
+			case "post-commit":
+				commits, err = gitlog.Log(root, "-1", "--no-merges", author, "HEAD")
+			case "pre-push":
+				commits, err = pushedCommits(root, author)
+			default:
+				log.Fatalf("Unknown hook: %s", args[0])
+			}
+			if err != nil {
+				log.Fatalf("Failed to read commits: %v", err)
+			}
+
+			var recorded []pending.Commit
+			for _, commit := range commits {
+				recorded = append(recorded, pending.Commit{RepoPath: root, Commit: commit})
+			}
+
+			added, err := loadPendingBuffer().Add(recorded...)
+			if err != nil {
+				log.Fatalf("Failed to record commits: %v", err)
+			}
+			if added > 0 {
+				fmt.Fprintf(os.Stderr, "harvest: recorded %d commit(s), log them with \"h create --from-pending\"\n", added)
+			}
+		},
+	}
+
+	return cmd
+}
+
+// pushedCommits returns the commits being pushed, read from the refs the pre-push hook gets on stdin
+func pushedCommits(root, author string) ([]gitlog.Commit, error) {
+	const zeroHash = "0000000000000000000000000000000000000000"
+
+	var commits []gitlog.Commit
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		// <local ref> <local sha> <remote ref> <remote sha>
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 || fields[1] == zeroHash {
+			continue // Deleted refs have no commits
+		}
+
+		args := []string{"--no-merges", author}
+		if fields[3] == zeroHash {
+			// New branch: commits not on any remote yet
+			args = append(args, fields[1], "--not", "--remotes")
+		} else {
+			args = append(args, fields[3]+".."+fields[1])
+		}
+
+		refCommits, err := gitlog.Log(root, args...)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, refCommits...)
+	}
+
+	return commits, scanner.Err()
+}
+
+// handlePendingMode creates time entries from the commits recorded by the git hooks
+// and removes them from the buffer once logged
+func handlePendingMode(repos []string, date, projectName, taskName, timeValue string) {
+	if date != "" {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+		}
+	}
+
+	// Only use the commits of the given repositories
+	var repoFilter []string
+	for _, repo := range repos {
+		path, err := config.ExpandPath(repo)
+		if err == nil {
+			path, err = gitlog.TopLevel(path)
+		}
+		if err != nil {
+			log.Fatalf("Invalid repository %s: %v", repo, err)
+		}
+		repoFilter = append(repoFilter, path)
+	}
+
+	buffer := loadPendingBuffer()
+
+	var roots []string
+	commitsByRoot := make(map[string][]gitlog.Commit)
+	for _, commit := range buffer.Commits() {
+		if len(repoFilter) > 0 && !containsString(repoFilter, commit.RepoPath) {
+			continue
+		}
+		if date != "" && commit.Time.Local().Format("2006-01-02") != date {
+			continue
+		}
+
+		if _, exists := commitsByRoot[commit.RepoPath]; !exists {
+			roots = append(roots, commit.RepoPath)
+		}
+		commitsByRoot[commit.RepoPath] = append(commitsByRoot[commit.RepoPath], commit.Commit)
+	}
+
+	if len(roots) == 0 {
+		fmt.Println("No pending commits. Use \"h hooks install\" to record commits in a repository.")
+		return
+	}
+
+	groups := groupGitCommits(roots, commitsByRoot, projectName, taskName)
+	if groups == nil {
+		return
+	}
+	if timeValue != "" && len(groups) > 1 {
+		log.Fatalf("The -t flag can only be used when all pending commits belong to the same day, project and task")
+	}
+
+	for _, group := range groups {
+		if !createGitEntry(group, timeValue) {
+			continue
+		}
+
+		// The commits are logged, clear them from the buffer
+		var hashes []string
+		for _, commits := range group.Commits {
+			for _, commit := range commits {
+				hashes = append(hashes, commit.Hash)
+			}
+		}
+		if err := buffer.Remove(hashes); err != nil {
+			log.Fatalf("Failed to clear pending commits: %v", err)
+		}
+	}
+}
+
+// loadPendingBuffer loads the pending work buffer from the data directory
+func loadPendingBuffer() *pending.Buffer {
+	dataDir, err := appConfig.GetDataDir()
+	if err != nil {
+		log.Fatalf("Failed to get data directory: %v", err)
+	}
+
+	buffer, err := pending.Load(dataDir)
+	if err != nil {
+		log.Fatalf("Failed to load pending work: %v", err)
+	}
+	return buffer
+}
+
+// hookRepository returns the root of the repository given as argument or the current directory
+func hookRepository(args []string) string {
+	repo := "."
+	if len(args) > 0 {
+		repo = args[0]
+	}
+
+	path, err := config.ExpandPath(repo)
+	if err != nil {
+		log.Fatalf("Invalid repository path %s: %v", repo, err)
+	}
+	root, err := gitlog.TopLevel(path)
+	if err != nil {
+		log.Fatalf("Not a git repository: %v", err)
+	}
+	return root
+}
+
+// validateHooks checks that only supported hooks are given
+func validateHooks(hooks []string) {
+	for _, hook := range hooks {
+		if !containsString(supportedHooks, hook) {
+			log.Fatalf("Unsupported hook: %s, expected %s", hook, strings.Join(supportedHooks, " or "))
+		}
+	}
+}
+
+// installHook adds the harvest-cli block to a hook script, replacing a previously installed block
+func installHook(path, block string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	script := removeHookBlock(string(content))
+	if strings.TrimSpace(script) == "" {
+		script = "#!/bin/sh\n"
+	}
+	if !strings.HasSuffix(script, "\n") {
+		script += "\n"
+	}
+
+	if err := os.WriteFile(path, []byte(script+block), 0755); err != nil {
+		return err
+	}
+	return os.Chmod(path, 0755)
+}
+
+// uninstallHook removes the harvest-cli block from a hook script, and the script if nothing else is left
+func uninstallHook(path string) (bool, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !strings.Contains(string(content), hookBlockStart) {
+		return false, nil
+	}
+
+	script := removeHookBlock(string(content))
+	if strings.TrimSpace(strings.TrimPrefix(script, "#!/bin/sh")) == "" {
+		return true, os.Remove(path)
+	}
+	return true, os.WriteFile(path, []byte(script), 0755)
+}
+
+// removeHookBlock returns a hook script without the harvest-cli block
+func removeHookBlock(script string) string {
+	start := strings.Index(script, hookBlockStart)
+	if start < 0 {
+		return script
+	}
+
+	end := strings.Index(script[start:], hookBlockEnd)
+	if end < 0 {
+		return script[:start]
+	}
+	end += start + len(hookBlockEnd)
+	if end < len(script) && script[end] == '\n' {
+		end++
+	}
+
+	return script[:start] + script[end:]
+}
+
+// shellQuote quotes a string for a POSIX shell
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
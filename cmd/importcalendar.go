@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"harvest-cli/pkg/ics"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// calendarMeeting represents a meeting that can be imported as a time entry
+type calendarMeeting struct {
+	Event   ics.Event
+	Project *config.Project // Project from the calendar rules, nil if unmapped
+	Task    *config.Task
+	Logged  bool // The notes of a time entry on the day contain the meeting title
+}
+
+// calendarTarget is a calendar rule with its project and task resolved from the configuration
+type calendarTarget struct {
+	Rule    *config.CalendarRule
+	Project *config.Project
+	Task    *config.Task
+}
+
+// ImportCalendarCmd returns the import-calendar command
+func ImportCalendarCmd() *cobra.Command {
+	var date, projectName, taskName string
+
+	cmd := &cobra.Command{
+		Use:   "import-calendar <file.ics>",
+		Short: "Create time entries from calendar meetings",
+		Long: `Create time entries from the meetings of a day in an iCalendar (.ics) file.
+Example: h import-calendar ~/Downloads/calendar.ics -d 2024-03-04
+
+Meetings are filtered by calendar_filters and mapped to projects and tasks by the
+title patterns in calendar_rules. Select the meetings to import from the checklist;
+each one is created with its title as notes and its duration.
+Use -p and -a flags to set the project and task of meetings without a matching rule.`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			handleImportCalendar(client, args[0], date, projectName, taskName)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringVarP(&date, "date", "d", time.Now().Format("2006-01-02"), "Date in YYYY-MM-DD format (default: today)")
+	cmd.Flags().StringVarP(&projectName, "project", "p", "", "Project of meetings without a matching rule")
+	cmd.Flags().StringVarP(&taskName, "action", "a", "", "Task of meetings without a matching rule")
+
+	return cmd
+}
+
+// handleImportCalendar imports the selected meetings of a day as time entries
+func handleImportCalendar(client *harvest.Client, path, date, projectName, taskName string) {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+	}
+
+	// Rules referring to unknown projects or tasks are reported before anything is imported
+	targets := resolveCalendarRules()
+
+	events, err := ics.ParseFile(path)
+	if err != nil {
+		log.Fatalf("Failed to read calendar: %v", err)
+	}
+	events, unsupported := ics.Occurrences(events, day, day.AddDate(0, 0, 1))
+	for _, err := range unsupported {
+		fmt.Printf("Skipped recurring event: %v\n", err)
+	}
+
+	// Meetings already logged are not selected by default
+	fmt.Printf("Fetching time entries for %s...\n", date)
+	timeEntries, err := client.GetAllTimeEntries(timeEntryParams(date, date))
+	if err != nil {
+		fmt.Printf("Could not check for meetings already logged: %v\n", err)
+	}
+
+	var meetings []calendarMeeting
+	skipped := 0
+	for _, event := range events {
+		if event.AllDay || event.IsCancelled() || !event.End.After(event.Start) || !matchesCalendarFilters(event) {
+			skipped++
+			continue
+		}
+
+		meeting := calendarMeeting{
+			Event:  event,
+			Logged: isMeetingLogged(event, timeEntries),
+		}
+		meeting.Project, meeting.Task = calendarRuleTarget(targets, event.Summary)
+		meetings = append(meetings, meeting)
+	}
+
+	if skipped > 0 {
+		fmt.Printf("Skipped %d all-day, cancelled or filtered events\n", skipped)
+	}
+	if len(meetings) == 0 {
+		fmt.Printf("No meetings to import on %s\n", date)
+		return
+	}
+
+	// Build the checklist, with the meetings that aren't logged yet selected
+	var labels []string
+	preselected := make(map[int]bool)
+	for i, meeting := range meetings {
+		target := "no matching rule"
+		if meeting.Project != nil {
+			target = meeting.Project.Name + " | " + meeting.Task.Name
+		}
+
+		label := fmt.Sprintf("%s-%s (%s) %s -> %s",
+			meeting.Event.Start.Format("15:04"),
+			meeting.Event.End.Format("15:04"),
+			formatMeetingDuration(meeting.Event),
+			meeting.Event.Summary,
+			target)
+		if meeting.Logged {
+			label += " [already logged]"
+		} else {
+			preselected[i] = true
+		}
+		labels = append(labels, label)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	checklist := MultiSelect{
+		Title:    fmt.Sprintf("Meetings on %s", date),
+		Action:   "import",
+		Items:    labels,
+		Selected: preselected,
+	}
+
+	indices, ok := checklist.Run(reader)
+	if !ok {
+		return
+	}
+	if len(indices) == 0 {
+		fmt.Println("No meetings selected. Operation cancelled.")
+		return
+	}
+
+	// Confirm the import
+	fmt.Printf("Create %d time entries? (y/n): ", len(indices))
+	confirm, _ := reader.ReadString('\n')
+	confirm = strings.TrimSpace(strings.ToLower(confirm))
+
+	if confirm != "y" && confirm != "yes" {
+		fmt.Println("Import cancelled")
+		return
+	}
+
+	var createdCount, cancelledCount int
+	for _, index := range indices {
+		meeting := meetings[index]
+		fmt.Printf("\n%s (%s)\n", meeting.Event.Summary, meeting.Event.Start.Format("15:04"))
+
+		// Meetings without a rule use the flags or a prompt
+		project, task := meeting.Project, meeting.Task
+		if project == nil {
+			project = selectProject(projectName)
+			if project == nil {
+				cancelledCount++
+				continue
+			}
+			task = selectTask(project, taskName)
+			if task == nil {
+				cancelledCount++
+				continue
+			}
+		}
+
+		entry := TimeEntry{
+			Date:      date,
+			ProjectID: project.ID,
+			TaskID:    task.ID,
+			Time:      math.Round(meeting.Event.End.Sub(meeting.Event.Start).Minutes()) / 60,
+			Notes:     meeting.Event.Summary,
+			Started:   harvest.FormatClockTime(meeting.Event.Start),
+			Ended:     harvest.FormatClockTime(meeting.Event.End),
+		}
+		if createHarvestTimeEntry(&entry) {
+			createdCount++
+		} else {
+			cancelledCount++
+		}
+	}
+
+	// Summary
+	fmt.Println("\nImport Summary:")
+	fmt.Println("-----------------------------------")
+	fmt.Printf("Total: %d meetings\n", len(indices))
+	fmt.Printf("Created: %d\n", createdCount)
+	fmt.Printf("Cancelled: %d\n", cancelledCount)
+	fmt.Println("-----------------------------------")
+}
+
+// matchesCalendarFilters reports whether a meeting passes the calendar filters
+func matchesCalendarFilters(event ics.Event) bool {
+	hasIncludes := false
+	included := false
+
+	for _, filter := range appConfig.CalendarFilters {
+		matched := matchesCalendarFilter(event, filter)
+		if filter.Exclude {
+			if matched {
+				return false
+			}
+			continue
+		}
+
+		hasIncludes = true
+		if matched {
+			included = true
+		}
+	}
+
+	return !hasIncludes || included
+}
+
+// matchesCalendarFilter reports whether a meeting matches both the attendee and keyword of a filter
+func matchesCalendarFilter(event ics.Event, filter config.CalendarFilter) bool {
+	if filter.Attendee != "" {
+		attendee := event.Attendee(filter.Attendee)
+		isOrganizer := strings.EqualFold(event.Organizer, filter.Attendee)
+		if (attendee == nil && !isOrganizer) || (attendee != nil && attendee.Status == "DECLINED") {
+			return false
+		}
+	}
+
+	if filter.Keyword != "" {
+		keyword := strings.ToLower(filter.Keyword)
+		if !strings.Contains(strings.ToLower(event.Summary), keyword) &&
+			!strings.Contains(strings.ToLower(event.Description), keyword) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// resolveCalendarRules looks up the project and task of every calendar rule
+func resolveCalendarRules() []calendarTarget {
+	var targets []calendarTarget
+
+	for i, rule := range appConfig.CalendarRules {
+		project := appConfig.GetProjectByName(rule.Project)
+		if project == nil {
+			log.Fatalf("Project '%s' of calendar rule %q not found in configuration", rule.Project, rule.Pattern)
+		}
+
+		taskName := rule.Task
+		if taskName == "" {
+			taskName = appConfig.DefaultTask
+		}
+		task := project.GetTaskByName(taskName)
+		if task == nil {
+			log.Fatalf("Task '%s' of calendar rule %q not found in project '%s'", taskName, rule.Pattern, project.Name)
+		}
+
+		targets = append(targets, calendarTarget{Rule: &appConfig.CalendarRules[i], Project: project, Task: task})
+	}
+
+	return targets
+}
+
+// calendarRuleTarget returns the project and task of the first calendar rule matching a meeting title
+func calendarRuleTarget(targets []calendarTarget, title string) (*config.Project, *config.Task) {
+	for _, target := range targets {
+		if target.Rule.Matches(title) {
+			return target.Project, target.Task
+		}
+	}
+
+	return nil, nil
+}
+
+// isMeetingLogged reports whether the notes of a time entry contain the meeting title
+func isMeetingLogged(event ics.Event, timeEntries []harvest.TimeEntry) bool {
+	title := strings.ToLower(strings.TrimSpace(event.Summary))
+	if title == "" {
+		return false
+	}
+
+	for _, entry := range timeEntries {
+		if strings.Contains(strings.ToLower(entry.Notes), title) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatMeetingDuration formats the duration of a meeting as HH:MM
+func formatMeetingDuration(event ics.Event) string {
+	minutes := int(math.Round(event.End.Sub(event.Start).Minutes()))
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/harvest"
+	"harvest-cli/pkg/journal"
+)
+
+// openJournal opens the operation journal in the data directory
+func openJournal() (*journal.Journal, error) {
+	dataDir, err := appConfig.GetDataDir()
+	if err != nil {
+		return nil, err
+	}
+	return journal.Open(dataDir), nil
+}
+
+// recordOperation records a create, update or delete of a time entry in the journal so it can be undone.
+// Failing to record an operation only prints a warning, the change in Harvest has already been made.
+func recordOperation(action string, before, after *harvest.TimeEntry) {
+	appendOperation(&journal.Operation{
+		Action: action,
+		Before: before,
+		After:  after,
+	})
+}
+
+// appendOperation fills in the account and entry ID of an operation and appends it to the journal
+func appendOperation(op *journal.Operation) {
+	op.AccountID = appConfig.HarvestAPI.AccountID
+	if op.After != nil {
+		op.EntryID = op.After.ID
+	} else if op.Before != nil {
+		op.EntryID = op.Before.ID
+	}
+
+	j, err := openJournal()
+	if err == nil {
+		err = j.Append(op)
+	}
+	if err != nil {
+		fmt.Fprintf(warningOutput, "Warning: failed to record %s of time entry %d in the journal: %v\n", op.Action, op.EntryID, err)
+	}
+	// Cached time entries no longer match Harvest
+	invalidateCache()
+}
+
+// entryRequest builds a request that restores the fields of a time entry snapshot
+func entryRequest(entry *harvest.TimeEntry) *harvest.TimeEntry {
+	request := &harvest.TimeEntry{
+		SpentDate: entry.SpentDate,
+		ProjectID: int(entry.Project.ID),
+		TaskID:    int(entry.Task.ID),
+		Hours:     entry.Hours,
+		Notes:     entry.Notes,
+
+		StartedTime:       entry.StartedTime,
+		EndedTime:         entry.EndedTime,
+		ExternalReference: entry.ExternalReference,
+	}
+
+	// Snapshots of requests only carry the IDs
+	if request.ProjectID == 0 {
+		request.ProjectID = entry.ProjectID
+	}
+	if request.TaskID == 0 {
+		request.TaskID = entry.TaskID
+	}
+
+	return request
+}
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"harvest-cli/pkg/calendar"
 	"harvest-cli/pkg/config"
 	"harvest-cli/pkg/harvest"
 	"log"
 	"os"
 	"sort"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -27,10 +30,25 @@ type TaskSummary struct {
 	TotalHours float64
 }
 
+// listUserID restricts listed time entries to a single user, empty for no restriction
+var listUserID string
+
+// listAllUsers is set when listing the time entries of all users
+var listAllUsers bool
+
+// listOtherUser is set when listing the time entries of another user than the authenticated one
+var listOtherUser bool
+
+// showsOwnEntries reports whether the listed entries are the authenticated user's, so that the
+// targets and capacity from the configuration apply to them
+func showsOwnEntries() bool {
+	return !listAllUsers && !listOtherUser
+}
+
 // ListCmd returns the list command
 func ListCmd() *cobra.Command {
 	var monthly, weekly, yearly bool
-	var date string
+	var date, user string
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -40,7 +58,13 @@ By default, lists all time entries for the current day.
 Use -d flag to specify a date (YYYY-MM-DD format).
 Use -w flag for weekly summary.
 Use -m flag for monthly summary.
-Use -y flag for yearly summary (based on year_start_date in config, defaults to January 1st).`,
+Use -y flag for yearly summary (based on year_start_date in config, defaults to January 1st).
+Use -u flag to list the entries of another user (name or ID), "me" or "all" users.
+Listing other users requires manager or administrator permissions in Harvest.
+
+Weekly, monthly and yearly summaries use a cache of time entries in the data directory
+that is refreshed with the entries updated since the last run.
+Use --refresh flag to fetch the entries again in full, or --no-cache flag to bypass the cache.`,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			// Load configuration
 			var err error
@@ -51,7 +75,7 @@ Use -y flag for yearly summary (based on year_start_date in config, defaults to
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			// Create Harvest API client
-			client := harvest.NewClient(&appConfig.HarvestAPI)
+			client := newHarvestClient()
 
 			// Parse the date if provided
 			var targetDate time.Time
@@ -65,6 +89,11 @@ Use -y flag for yearly summary (based on year_start_date in config, defaults to
 				targetDate = time.Now()
 			}
 
+			// Resolve the user filter
+			if user != "" {
+				resolveListUser(client, user)
+			}
+
 			if yearly {
 				// Yearly summary
 				handleYearlySummary(client, targetDate)
@@ -86,26 +115,104 @@ Use -y flag for yearly summary (based on year_start_date in config, defaults to
 	cmd.Flags().BoolVarP(&weekly, "weekly", "w", false, "Show weekly summary")
 	cmd.Flags().BoolVarP(&yearly, "yearly", "y", false, "Show yearly summary")
 	cmd.Flags().StringVarP(&date, "date", "d", "", "Date in YYYY-MM-DD format (default: today)")
+	cmd.Flags().StringVarP(&user, "user", "u", "", "User name or ID, \"me\" or \"all\" (default: entries visible to the token)")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Fetch time entries from Harvest without using the cache")
+	cmd.Flags().BoolVar(&refreshCache, "refresh", false, "Fetch cached time entries again in full")
 
 	return cmd
 }
 
+// resolveListUser sets the user filter from a user name, user ID, "me" or "all"
+func resolveListUser(client *harvest.Client, user string) {
+	switch strings.ToLower(user) {
+	case "all":
+		listAllUsers = true
+		return
+	case "me":
+		currentUser, err := client.GetCurrentUser()
+		if err != nil {
+			log.Fatalf("Failed to get current user: %v", err)
+		}
+		listUserID = strconv.FormatInt(currentUser.ID, 10)
+		return
+	}
+
+	users, err := client.ListUsers(nil)
+	if err != nil {
+		log.Fatalf("Failed to get users: %v", err)
+	}
+
+	selected := findUser(users, user)
+	if selected == nil {
+		log.Fatalf("User '%s' not found", user)
+	}
+
+	currentUser, err := client.GetCurrentUser()
+	if err != nil {
+		log.Fatalf("Failed to get current user: %v", err)
+	}
+
+	fmt.Printf("Listing time entries of %s\n", selected.FullName())
+	listUserID = strconv.FormatInt(selected.ID, 10)
+	listOtherUser = selected.ID != currentUser.ID
+}
+
+// findUser finds a user by ID, exact name or case-insensitive name substring
+func findUser(users []harvest.User, query string) *harvest.User {
+	if id, err := strconv.ParseInt(query, 10, 64); err == nil {
+		for i, user := range users {
+			if user.ID == id {
+				return &users[i]
+			}
+		}
+	}
+
+	var match *harvest.User
+	for i, user := range users {
+		name := user.FullName()
+		if strings.EqualFold(name, query) || strings.EqualFold(user.Email, query) {
+			return &users[i]
+		}
+		if match == nil && strings.Contains(strings.ToLower(name), strings.ToLower(query)) {
+			match = &users[i]
+		}
+	}
+
+	return match
+}
+
+// timeEntryParams returns the parameters for fetching the listed time entries between two dates
+func timeEntryParams(from, to string) map[string]string {
+	params := map[string]string{
+		"from": from,
+		"to":   to,
+	}
+	if listUserID != "" {
+		params["user_id"] = listUserID
+	}
+	return params
+}
+
 // handleDailyList handles listing time entries for a specific day
 func handleDailyList(client *harvest.Client, date string) {
 	// Get time entries for the specified date
-	params := map[string]string{
-		"from": date,
-		"to":   date,
-	}
+	params := timeEntryParams(date, date)
 
 	fmt.Printf("Fetching time entries for %s...\n", date)
 	timeEntries, err := client.GetTimeEntries(params)
-	if err != nil {
+	if harvest.IsNetworkError(err) {
+		fmt.Printf("Harvest is unreachable, showing queued changes only: %v\n", err)
+	} else if err != nil {
 		log.Fatalf("Failed to get time entries: %v", err)
 	}
 
+	// Include the changes waiting for "h sync"
+	pendingStatus, pendingEntries := pendingChanges(date)
+	timeEntries = append(timeEntries, pendingEntries...)
+
 	if len(timeEntries) == 0 {
 		fmt.Printf("No time entries found for %s\n", date)
+		displayFillTarget(date, date, 0)
 		return
 	}
 
@@ -116,8 +223,13 @@ func handleDailyList(client *harvest.Client, date string) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
 	// Print table header
-	fmt.Fprintln(w, "ID\tProject (ID) | Task (ID)\tNotes\tDuration")
-	fmt.Fprintln(w, "----\t------------------------\t--------------------\t--------")
+	if listAllUsers {
+		fmt.Fprintln(w, "ID\tUser\tProject (ID) | Task (ID)\tNotes\tStart\tEnd\tDuration\tStatus")
+		fmt.Fprintln(w, "----\t----\t------------------------\t--------------------\t-----\t-----\t--------\t------")
+	} else {
+		fmt.Fprintln(w, "ID\tProject (ID) | Task (ID)\tNotes\tStart\tEnd\tDuration\tStatus")
+		fmt.Fprintln(w, "----\t------------------------\t--------------------\t-----\t-----\t--------\t------")
+	}
 
 	var totalHours float64
 	taskHours := make(map[string]float64)
@@ -136,15 +248,41 @@ func handleDailyList(client *harvest.Client, date string) {
 			notes = notes[:27] + "..."
 		}
 
-		// Format duration
+		// Format duration and wall clock times
 		duration := fmt.Sprintf("%02d:%02d", hours, minutes)
+		start, end := formatClockRange(entry)
+
+		// Queued entries have no ID yet
+		id := "queued"
+		if entry.ID != 0 {
+			id = strconv.FormatInt(entry.ID, 10)
+		}
+		status := entryStatus(entry)
+		if pending, ok := pendingStatus[entry.ID]; ok {
+			status = pending
+		}
 
 		// Print table row
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n",
-			entry.ID,
-			projectTaskInfo,
-			notes,
-			duration)
+		if listAllUsers {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				id,
+				entry.User.FullName(),
+				projectTaskInfo,
+				notes,
+				start,
+				end,
+				duration,
+				status)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				id,
+				projectTaskInfo,
+				notes,
+				start,
+				end,
+				duration,
+				status)
+		}
 
 		totalHours += entry.Hours
 
@@ -155,9 +293,15 @@ func handleDailyList(client *harvest.Client, date string) {
 	// Flush the tabwriter
 	w.Flush()
 
+	// Overlaps only matter within a user's own entries
+	if !listAllUsers {
+		displayOverlaps(timeEntries)
+	}
+
 	// Print total
 	totalHoursInt, totalMinutes := convertDecimalToHoursMinutes(totalHours)
 	fmt.Printf("\nTotal: %02d:%02d hours\n", totalHoursInt, totalMinutes)
+	displayFillTarget(date, date, totalHours)
 
 	// Print task-based aggregation
 	fmt.Println("\nTime by Task:")
@@ -192,15 +336,8 @@ func handleDailyList(client *harvest.Client, date string) {
 
 // handleWeeklySummary handles showing a weekly summary of time entries
 func handleWeeklySummary(client *harvest.Client, targetDate time.Time) {
-	// Calculate the start of the week (Monday)
-	weekday := targetDate.Weekday()
-	if weekday == 0 { // Sunday
-		weekday = 7
-	}
-	startOfWeek := targetDate.AddDate(0, 0, -int(weekday-1))
-
-	// Initialize with the specified week
-	showWeeklySummary(client, startOfWeek)
+	// Initialize with the week starting on Monday
+	showWeeklySummary(client, startOfWeek(targetDate, time.Monday))
 }
 
 // showWeeklySummary shows a summary for a specific week
@@ -214,13 +351,10 @@ func showWeeklySummary(client *harvest.Client, startDate time.Time) {
 	displayDateRange := fmt.Sprintf("%s to %s", startDate.Format("Jan 2"), endDate.Format("Jan 2, 2006"))
 
 	// Get time entries for the week
-	params := map[string]string{
-		"from": startDateStr,
-		"to":   endDateStr,
-	}
+	params := timeEntryParams(startDateStr, endDateStr)
 
 	fmt.Printf("Fetching time entries for week of %s...\n", displayDateRange)
-	timeEntries, err := client.GetTimeEntries(params)
+	timeEntries, err := fetchTimeEntries(client, params)
 	if err != nil {
 		log.Fatalf("Failed to get time entries: %v", err)
 	}
@@ -298,6 +432,7 @@ func showWeeklySummary(client *harvest.Client, startDate time.Time) {
 	// Print total
 	totalHoursInt, totalMinutes := convertDecimalToHoursMinutes(totalHours)
 	fmt.Printf("\nTotal: %02d:%02d hours\n", totalHoursInt, totalMinutes)
+	displayFillTarget(startDateStr, endDateStr, totalHours)
 
 	// Print task-based aggregation
 	fmt.Println("\nTime by Task (across all projects):")
@@ -353,13 +488,10 @@ func showMonthlySummary(client *harvest.Client, startDate time.Time) {
 	displayMonth := startDate.Format("January 2006")
 
 	// Get time entries for the month
-	params := map[string]string{
-		"from": startDateStr,
-		"to":   endDateStr,
-	}
+	params := timeEntryParams(startDateStr, endDateStr)
 
 	fmt.Printf("Fetching time entries for %s...\n", displayMonth)
-	timeEntries, err := client.GetTimeEntries(params)
+	timeEntries, err := fetchTimeEntries(client, params)
 	if err != nil {
 		log.Fatalf("Failed to get time entries: %v", err)
 	}
@@ -375,19 +507,18 @@ func showMonthlySummary(client *harvest.Client, startDate time.Time) {
 	// Display monthly summary
 	fmt.Printf("\nMonthly Summary (%s):\n", displayMonth)
 
-	// Calculate capacity and utilization metrics
-	monthlyCapacity := appConfig.GetMonthlyCapacityHours()
-
-	// Calculate period length in months (should be 1.0 for a complete month)
-	periodLength := calculateMonthsBetween(startDate, endDate.AddDate(0, 0, 1))
-	periodCapacity := monthlyCapacity * periodLength
+	// Calculate capacity from the working days in the month
+	period := loadCalendar().Period(startDate, endDate)
+	periodCapacity := period.Capacity
 
 	var totalHours float64
 	var billableHours float64
+	var billableAmount float64
 
 	// Create maps for task summaries
 	taskSummaries := make(map[string]float64)
 	billableTaskSummaries := make(map[string]float64)
+	billableTaskAmounts := make(map[string]float64)
 	nonBillableTaskSummaries := make(map[string]float64)
 
 	// Track project-wise hours
@@ -404,42 +535,28 @@ func showMonthlySummary(client *harvest.Client, startDate time.Time) {
 		projectHours[projectName] += hours
 		totalHours += hours
 
-		// Check if task is billable
-		if appConfig.IsBillableTask(int(entry.Task.ID)) {
+		// Check if the entry is billable
+		if appConfig.IsBillableEntry(int(entry.Task.ID), entry.Billable) {
 			billableHours += hours
 			billableTaskSummaries[taskName] += hours
+			billableAmount += entry.BillableAmount()
+			billableTaskAmounts[taskName] += entry.BillableAmount()
 		} else {
 			nonBillableTaskSummaries[taskName] += hours
 		}
 	}
 
-	// Calculate overtime or remaining capacity hours
-	leaveHours := billableHours - periodCapacity
-	leaveDays := leaveHours / 8.0 // Converting hours to days based on 8-hour workdays
-
-	// Display simplified capacity metrics
-	fmt.Printf("\nCapacity Metrics:\n")
-	fmt.Printf("- Period Length: %.2f months\n", periodLength)
-	fmt.Printf("- Period Capacity: %.2f hours\n", periodCapacity)
-	fmt.Printf("- Total Hours: %.2f hours\n", totalHours)
-	fmt.Printf("- Billable Hours: %.2f hours\n", billableHours)
-
-	// Display overtime or remaining capacity
-	if leaveDays >= 0 {
-		fmt.Printf("- Overtime (in days): %.2f days (%.2f hours)\n",
-			leaveDays, leaveHours)
-	} else {
-		fmt.Printf("- Capacity Remaining (in days): %.2f days (%.2f hours)\n",
-			-leaveDays, -leaveHours)
-	}
+	// Display capacity metrics
+	fmt.Println()
+	displayCapacityMetrics(period, totalHours, billableHours, billableAmount)
 
 	fmt.Println("\nBillable Tasks Summary:")
 	fmt.Println("------------------------")
 
 	// Create a tabwriter for tasks
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "Task\tHours\t% of Total\t% of Capacity")
-	fmt.Fprintln(w, "----\t-----\t-----------\t------------")
+	fmt.Fprintln(w, "Task\tHours\t% of Total\t% of Capacity\tAmount")
+	fmt.Fprintln(w, "----\t-----\t-----------\t------------\t------")
 
 	// Sort and display billable tasks first
 	var billableTaskNames []string
@@ -453,18 +570,20 @@ func showMonthlySummary(client *harvest.Client, startDate time.Time) {
 		percentOfTotal := (hours / totalHours) * 100
 		percentOfCapacity := (hours / periodCapacity) * 100
 
-		fmt.Fprintf(w, "%s\t%.2f\t%.1f%%\t%.1f%%\n",
+		fmt.Fprintf(w, "%s\t%.2f\t%.1f%%\t%.1f%%\t%s\n",
 			taskName,
 			hours,
 			percentOfTotal,
-			percentOfCapacity)
+			percentOfCapacity,
+			formatAmount(billableTaskAmounts[taskName]))
 	}
 
 	// Calculate billable totals
-	fmt.Fprintf(w, "TOTAL BILLABLE\t%.2f\t%.1f%%\t%.1f%%\n",
+	fmt.Fprintf(w, "TOTAL BILLABLE\t%.2f\t%.1f%%\t%.1f%%\t%s\n",
 		billableHours,
 		(billableHours/totalHours)*100,
-		(billableHours/periodCapacity)*100)
+		(billableHours/periodCapacity)*100,
+		formatAmount(billableAmount))
 
 	w.Flush()
 
@@ -497,10 +616,30 @@ func showMonthlySummary(client *harvest.Client, startDate time.Time) {
 
 	w.Flush()
 
+	// Display expense totals for the month
+	displayMonthlyExpenses(client, startDateStr, endDateStr)
+
 	// Offer navigation options
 	handleSummaryNavigation(client, startDate, "month")
 }
 
+// displayMonthlyExpenses prints the expense totals per category between two dates
+func displayMonthlyExpenses(client *harvest.Client, from, to string) {
+	params := timeEntryParams(from, to)
+	expenses, err := client.ListExpenses(params)
+	if err != nil {
+		fmt.Printf("\nCould not get expenses: %v\n", err)
+		return
+	}
+
+	if len(expenses) == 0 {
+		fmt.Println("\nNo expenses logged in this month")
+		return
+	}
+
+	displayExpenseTotals(expenses)
+}
+
 // handleSummaryNavigation handles navigation between different time periods
 func handleSummaryNavigation(client *harvest.Client, currentDate time.Time, periodType string) {
 	options := []string{"Previous " + periodType, "Next " + periodType, "Exit"}
@@ -577,17 +716,7 @@ func handleYearlySummary(client *harvest.Client, targetDate time.Time) {
 	}
 
 	// Determine the year boundaries
-	var yearStart time.Time
-	currentYear := targetDate.Year()
-
-	// Create the start date for the current year cycle
-	yearStart = time.Date(currentYear, time.Month(startMonth), startDay, 0, 0, 0, 0, targetDate.Location())
-
-	// If the target date is before the year start in the current calendar year,
-	// we need to use the previous calendar year's start date
-	if targetDate.Before(yearStart) {
-		yearStart = time.Date(currentYear-1, time.Month(startMonth), startDay, 0, 0, 0, 0, targetDate.Location())
-	}
+	yearStart := fiscalYearStart(targetDate, startMonth, startDay)
 
 	// Calculate the end date (next year's start - 1 day)
 	yearEnd := time.Date(yearStart.Year()+1, time.Month(startMonth), startDay, 0, 0, 0, 0, targetDate.Location()).AddDate(0, 0, -1)
@@ -601,23 +730,15 @@ func handleYearlySummary(client *harvest.Client, targetDate time.Time) {
 	// Format for API call and display
 	from := yearStart.Format("2006-01-02")
 	to := yearEnd.Format("2006-01-02")
-	yearLabel := fmt.Sprintf("%d/%d", yearStart.Year(), yearStart.Year()+1)
-
-	// If using standard calendar year, just show the year
-	if startMonth == 1 && startDay == 1 {
-		yearLabel = fmt.Sprintf("%d", yearStart.Year())
-	}
+	yearLabel := fiscalYearLabel(yearStart, startMonth, startDay)
 
 	fmt.Printf("Yearly Summary (%s)\n", yearLabel)
 	fmt.Printf("Period: %s to %s\n\n", from, to)
 
 	// Get time entries for the period
-	params := map[string]string{
-		"from": from,
-		"to":   to,
-	}
+	params := timeEntryParams(from, to)
 
-	entries, err := client.GetTimeEntries(params)
+	entries, err := fetchTimeEntries(client, params)
 	if err != nil {
 		log.Fatalf("Failed to get time entries: %v", err)
 	}
@@ -627,20 +748,19 @@ func handleYearlySummary(client *harvest.Client, targetDate time.Time) {
 		return
 	}
 
-	// Calculate period length in months
-	periodLength := calculateMonthsBetween(yearStart, yearEnd.AddDate(0, 0, 1))
-
-	// Calculate capacity based on monthly capacity
-	monthlyCapacity := appConfig.GetMonthlyCapacityHours()
-	yearlyCapacity := monthlyCapacity * periodLength
+	// Calculate capacity from the working days in the period
+	period := loadCalendar().Period(yearStart, yearEnd)
+	yearlyCapacity := period.Capacity
 
 	// Initialize counters
 	var totalHours float64
 	var billableHours float64
+	var billableAmount float64
 
 	// Create maps for task summaries
 	taskSummaries := make(map[string]float64)
 	billableTaskSummaries := make(map[string]float64)
+	billableTaskAmounts := make(map[string]float64)
 	nonBillableTaskSummaries := make(map[string]float64)
 
 	// Track project-wise hours
@@ -657,42 +777,27 @@ func handleYearlySummary(client *harvest.Client, targetDate time.Time) {
 		projectHours[projectName] += hours
 		totalHours += hours
 
-		// Check if task is billable
-		if appConfig.IsBillableTask(int(entry.Task.ID)) {
+		// Check if the entry is billable
+		if appConfig.IsBillableEntry(int(entry.Task.ID), entry.Billable) {
 			billableHours += hours
 			billableTaskSummaries[taskName] += hours
+			billableAmount += entry.BillableAmount()
+			billableTaskAmounts[taskName] += entry.BillableAmount()
 		} else {
 			nonBillableTaskSummaries[taskName] += hours
 		}
 	}
 
-	// Calculate overtime or remaining capacity hours
-	leaveHours := billableHours - yearlyCapacity
-	leaveDays := leaveHours / 8.0 // Converting hours to days based on 8-hour workdays
-
-	// Display simplified capacity metrics
-	fmt.Printf("Capacity Metrics:\n")
-	fmt.Printf("- Period Length: %.2f months\n", periodLength)
-	fmt.Printf("- Period Capacity: %.2f hours\n", yearlyCapacity)
-	fmt.Printf("- Total Hours: %.2f hours\n", totalHours)
-	fmt.Printf("- Billable Hours: %.2f hours\n", billableHours)
-
-	// Display overtime or remaining capacity
-	if leaveDays >= 0 {
-		fmt.Printf("- Overtime (in days): %.2f days (%.2f hours)\n",
-			leaveDays, leaveHours)
-	} else {
-		fmt.Printf("- Capacity Remaining (in days): %.2f days (%.2f hours)\n",
-			-leaveDays, -leaveHours)
-	}
+	// Display capacity metrics
+	displayCapacityMetrics(period, totalHours, billableHours, billableAmount)
 
 	fmt.Println("\nBillable Tasks Summary:")
 	fmt.Println("------------------------")
 
 	// Create a tabwriter for tasks
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "Task\tHours\t% of Total\t% of Capacity\t")
-	fmt.Fprintln(w, "----\t-----\t-----------\t------------\t")
+	fmt.Fprintln(w, "Task\tHours\t% of Total\t% of Capacity\tAmount\t")
+	fmt.Fprintln(w, "----\t-----\t-----------\t------------\t------\t")
 
 	// Sort and display billable tasks first
 	var billableTaskNames []string
@@ -706,18 +811,20 @@ func handleYearlySummary(client *harvest.Client, targetDate time.Time) {
 		percentOfTotal := (hours / totalHours) * 100
 		percentOfCapacity := (hours / yearlyCapacity) * 100
 
-		fmt.Fprintf(w, "%s\t%.2f\t%.1f%%\t%.1f%%\t\n",
+		fmt.Fprintf(w, "%s\t%.2f\t%.1f%%\t%.1f%%\t%s\t\n",
 			taskName,
 			hours,
 			percentOfTotal,
-			percentOfCapacity)
+			percentOfCapacity,
+			formatAmount(billableTaskAmounts[taskName]))
 	}
 
 	// Calculate billable totals
-	fmt.Fprintf(w, "TOTAL BILLABLE\t%.2f\t%.1f%%\t%.1f%%\t\n",
+	fmt.Fprintf(w, "TOTAL BILLABLE\t%.2f\t%.1f%%\t%.1f%%\t%s\t\n",
 		billableHours,
 		(billableHours/totalHours)*100,
-		(billableHours/yearlyCapacity)*100)
+		(billableHours/yearlyCapacity)*100,
+		formatAmount(billableAmount))
 
 	w.Flush()
 
@@ -751,38 +858,102 @@ func handleYearlySummary(client *harvest.Client, targetDate time.Time) {
 	w.Flush()
 }
 
-// calculateMonthsBetween calculates the number of months between two dates
-func calculateMonthsBetween(start, end time.Time) float64 {
-	// Handle same day or invalid range
-	if end.Before(start) || end.Equal(start) {
-		return 0.0
+// fiscalYearStart returns the start of the year cycle containing the target date
+func fiscalYearStart(targetDate time.Time, startMonth, startDay int) time.Time {
+	// Create the start date for the current year cycle
+	yearStart := time.Date(targetDate.Year(), time.Month(startMonth), startDay, 0, 0, 0, 0, targetDate.Location())
+
+	// If the target date is before the year start in the current calendar year,
+	// we need to use the previous calendar year's start date
+	if targetDate.Before(yearStart) {
+		yearStart = time.Date(targetDate.Year()-1, time.Month(startMonth), startDay, 0, 0, 0, 0, targetDate.Location())
 	}
 
-	// Calculate complete months
-	completeMonths := 0
+	return yearStart
+}
 
-	// Start with the current month
-	currentMonth := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+// fiscalYearLabel returns the display label of the year cycle starting at yearStart
+func fiscalYearLabel(yearStart time.Time, startMonth, startDay int) string {
+	// If using standard calendar year, just show the year
+	if startMonth == 1 && startDay == 1 {
+		return fmt.Sprintf("%d", yearStart.Year())
+	}
+	return fmt.Sprintf("%d/%d", yearStart.Year(), yearStart.Year()+1)
+}
 
-	// Calculate months between start and end
-	for currentMonth.Before(end) {
-		nextMonth := currentMonth.AddDate(0, 1, 0)
-		// Only count if the entire month is within our range
-		if nextMonth.Before(end) || nextMonth.Equal(end) {
-			completeMonths++
-		}
-		currentMonth = nextMonth
+// loadCalendar loads the holiday and leave calendar from the configuration
+func loadCalendar() *calendar.Calendar {
+	cal, err := calendar.Load(appConfig)
+	if err != nil {
+		log.Fatalf("Failed to load holiday calendar: %v", err)
+	}
+	return cal
+}
+
+// displayFillTarget prints the scheduled hours for a date range and how much is left to log
+func displayFillTarget(from, to string, loggedHours float64) {
+	// Targets are personal, they do not apply to the entries of other users
+	if !showsOwnEntries() {
+		return
+	}
+
+	fromDate, _ := time.Parse("2006-01-02", from)
+	toDate, _ := time.Parse("2006-01-02", to)
+	target := loadCalendar().Period(fromDate, toDate).Capacity
+
+	targetHours, targetMinutes := convertDecimalToHoursMinutes(target)
+	if loggedHours >= target {
+		fmt.Printf("Target: %02d:%02d hours (filled)\n", targetHours, targetMinutes)
+		return
+	}
+
+	remainingHours, remainingMinutes := convertDecimalToHoursMinutes(target - loggedHours)
+	fmt.Printf("Target: %02d:%02d hours (remaining: %02d:%02d)\n",
+		targetHours, targetMinutes, remainingHours, remainingMinutes)
+}
+
+// formatAmount formats a monetary amount in the configured currency
+func formatAmount(amount float64) string {
+	return fmt.Sprintf("%.2f %s", amount, appConfig.GetCurrency())
+}
+
+// displayCapacityMetrics prints capacity, days off and overtime for a period
+func displayCapacityMetrics(period calendar.Period, totalHours, billableHours, billableAmount float64) {
+	// Capacity is personal, it does not apply to the entries of other users
+	if !showsOwnEntries() {
+		return
 	}
 
-	// Calculate days in the final partial month
-	var partialMonth float64 = 0.0
-	if start.AddDate(0, completeMonths, 0).Before(end) {
-		// Days in the final month
-		daysInMonth := time.Date(end.Year(), end.Month()+1, 0, 0, 0, 0, 0, end.Location()).Day()
-		// Calculate the fraction of the month
-		partialMonth = float64(end.Day()) / float64(daysInMonth)
+	fmt.Printf("Capacity Metrics:\n")
+	fmt.Printf("- Working Days: %.2f of %d days\n", period.WorkingDays, period.ScheduledDays)
+	fmt.Printf("- Period Capacity: %.2f hours\n", period.Capacity)
+	fmt.Printf("- Holidays: %.2f days\n", period.HolidayDays)
+	for _, dayOff := range period.Holidays {
+		fmt.Printf("    %s %s\n", dayOff.Date.Format("2006-01-02"), dayOff.Name)
+	}
+	fmt.Printf("- Leave Taken: %.2f days\n", period.LeaveDays)
+	for _, dayOff := range period.Leave {
+		fmt.Printf("    %s %s\n", dayOff.Date.Format("2006-01-02"), dayOff.Name)
 	}
+	fmt.Printf("- Total Hours: %.2f hours\n", totalHours)
+	fmt.Printf("- Billable Hours: %.2f hours\n", billableHours)
+	fmt.Printf("- Billable Amount: %s\n", formatAmount(billableAmount))
 
-	// Return complete months plus any partial month
-	return float64(completeMonths) + partialMonth
+	// Calculate overtime or remaining capacity hours, converted to days
+	// using the average length of a working day in the period
+	leaveHours := billableHours - period.Capacity
+	dailyHours := period.AverageDailyHours()
+	if dailyHours <= 0 {
+		dailyHours = appConfig.GetDailyHours()
+	}
+	leaveDays := leaveHours / dailyHours
+
+	// Display overtime or remaining capacity
+	if leaveDays >= 0 {
+		fmt.Printf("- Overtime (in days): %.2f days (%.2f hours)\n",
+			leaveDays, leaveHours)
+	} else {
+		fmt.Printf("- Capacity Remaining (in days): %.2f days (%.2f hours)\n",
+			-leaveDays, -leaveHours)
+	}
 }
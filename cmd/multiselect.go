@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MultiSelect is a numbered checklist in the terminal for selecting several items
+type MultiSelect struct {
+	Title      string                // Heading of the list, e.g. "Time entries for 2024-03-04"
+	Action     string                // What happens to the selected items, e.g. "deletion"
+	Items      []string              // Labels of the items
+	Disabled   func(index int) error // Returns why an item can't be selected, or nil
+	LockedNote string                // Explains the items marked with [L]
+	Selected   map[int]bool          // Preselected items
+}
+
+// Run shows the checklist until the user is done, returning the selected indices
+// in order, or false if the user quit
+func (m *MultiSelect) Run(reader *bufio.Reader) ([]int, bool) {
+	selected := make(map[int]bool)
+	for index, isSelected := range m.Selected {
+		if isSelected {
+			selected[index] = true
+		}
+	}
+
+	// Display instructions
+	fmt.Printf("\nMulti-select %s:\n", m.Title)
+	fmt.Println("-----------------------------------")
+	fmt.Println("Instructions:")
+	fmt.Println("- Enter the number of an entry to select/deselect it")
+	fmt.Println("- Enter 'a' to select all entries")
+	fmt.Println("- Enter 'n' to deselect all entries")
+	fmt.Printf("- Enter 'd' when done to proceed with %s\n", m.Action)
+	fmt.Println("- Enter 'q' to quit without changes")
+	if m.LockedNote != "" {
+		fmt.Printf("- %s\n", m.LockedNote)
+	}
+	fmt.Println("-----------------------------------")
+
+	// Main selection loop
+	for {
+		// Display the current list with selection status
+		fmt.Printf("\n%s:\n", m.Title)
+		fmt.Println("-----------------------------------")
+
+		for i, item := range m.Items {
+			mark := " "
+			if selected[i] {
+				mark = "X"
+			} else if m.isDisabled(i) != nil {
+				mark = "L"
+			}
+
+			fmt.Printf("[%d] [%s] %s\n", i+1, mark, item)
+		}
+
+		fmt.Println("-----------------------------------")
+		fmt.Printf("%d of %d entries selected\n", len(selected), len(m.Items))
+		fmt.Print("Enter selection (number, a, n, d, q): ")
+
+		input, err := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if err != nil && input == "" {
+			fmt.Println("\nOperation cancelled")
+			return nil, false
+		}
+
+		switch strings.ToLower(input) {
+		case "a": // Select all selectable entries
+			for i := range m.Items {
+				if m.isDisabled(i) == nil {
+					selected[i] = true
+				}
+			}
+		case "n": // Deselect all
+			selected = make(map[int]bool)
+		case "d": // Done
+			var indices []int
+			for i := range selected {
+				indices = append(indices, i)
+			}
+			sort.Ints(indices)
+			return indices, true
+		case "q": // Quit
+			fmt.Println("Operation cancelled")
+			return nil, false
+		default: // Try to parse as a number
+			num, err := strconv.Atoi(input)
+			if err == nil && num > 0 && num <= len(m.Items) {
+				idx := num - 1
+
+				// Disabled entries cannot be selected
+				if err := m.isDisabled(idx); err != nil {
+					fmt.Println(err)
+					continue
+				}
+
+				// Toggle selection
+				if selected[idx] {
+					delete(selected, idx)
+				} else {
+					selected[idx] = true
+				}
+			}
+		}
+	}
+}
+
+// isDisabled returns why an item can't be selected, or nil
+func (m *MultiSelect) isDisabled(index int) error {
+	if m.Disabled == nil {
+		return nil
+	}
+	return m.Disabled(index)
+}
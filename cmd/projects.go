@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// ProjectListing represents a project together with its task assignments
+type ProjectListing struct {
+	harvest.ProjectDetails
+	TaskAssignments []harvest.TaskAssignment `json:"task_assignments"`
+}
+
+// ProjectsCmd returns the projects command
+func ProjectsCmd() *cobra.Command {
+	var clientFilter string
+	var includeInactive, jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "projects [filter]",
+		Short: "List projects in Harvest",
+		Long: `List active projects with client, code, budget and assigned tasks.
+Example: h projects "Project A"
+
+Optionally filter by project name (case-insensitive substring), code or ID.
+Use -c flag to only list the projects of a client (name or ID).
+Use --all flag to include archived projects.
+Use --json flag to print the projects as JSON.`,
+		Args: cobra.MaximumNArgs(1),
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			var filter string
+			if len(args) > 0 {
+				filter = args[0]
+			}
+
+			handleProjects(client, filter, clientFilter, includeInactive, jsonOutput)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringVarP(&clientFilter, "client", "c", "", "Client name or ID")
+	cmd.Flags().BoolVar(&includeInactive, "all", false, "Include archived projects")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the projects as JSON")
+
+	return cmd
+}
+
+// handleProjects lists the projects matching the filters
+func handleProjects(client *harvest.Client, filter, clientFilter string, includeInactive, jsonOutput bool) {
+	params := make(map[string]string)
+	if !includeInactive {
+		params["is_active"] = "true"
+	}
+
+	// Resolve the client filter to a client ID
+	if clientFilter != "" {
+		clients, err := client.ListClients(nil)
+		if err != nil {
+			log.Fatalf("Failed to get clients: %v", err)
+		}
+
+		selected := findClient(clients, clientFilter)
+		if selected == nil {
+			log.Fatalf("Client '%s' not found", clientFilter)
+		}
+		params["client_id"] = strconv.FormatInt(selected.ID, 10)
+	}
+
+	if !jsonOutput {
+		fmt.Println("Fetching projects...")
+	}
+	projects, err := client.ListProjects(params)
+	if err != nil {
+		log.Fatalf("Failed to get projects: %v", err)
+	}
+
+	// Group task assignments by project, they require manager or administrator permissions
+	assignmentParams := make(map[string]string)
+	if !includeInactive {
+		assignmentParams["is_active"] = "true"
+	}
+	assignmentsByProject := make(map[int64][]harvest.TaskAssignment)
+	assignments, err := client.ListTaskAssignments(assignmentParams)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not get task assignments: %v\n", err)
+	}
+	for _, assignment := range assignments {
+		assignmentsByProject[assignment.Project.ID] = append(assignmentsByProject[assignment.Project.ID], assignment)
+	}
+
+	var listings []ProjectListing
+	for _, project := range projects {
+		if filter != "" && !matchesProjectFilter(project.ID, project.Name, project.Code, filter) {
+			continue
+		}
+		listings = append(listings, ProjectListing{
+			ProjectDetails:  project,
+			TaskAssignments: assignmentsByProject[project.ID],
+		})
+	}
+
+	sort.Slice(listings, func(i, j int) bool {
+		if listings[i].Client.Name != listings[j].Client.Name {
+			return listings[i].Client.Name < listings[j].Client.Name
+		}
+		return listings[i].Name < listings[j].Name
+	})
+
+	if jsonOutput {
+		printJSON(listings)
+		return
+	}
+
+	if len(listings) == 0 {
+		fmt.Println("No projects found")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tClient\tProject\tCode\tBudget\tTasks\tIn Config")
+	fmt.Fprintln(w, "----\t------\t-------\t----\t------\t-----\t---------")
+
+	for _, listing := range listings {
+		code := listing.Code
+		if code == "" {
+			code = "-"
+		}
+
+		var taskNames []string
+		for _, assignment := range listing.TaskAssignments {
+			taskNames = append(taskNames, assignment.Task.Name)
+		}
+		sort.Strings(taskNames)
+		tasks := strings.Join(taskNames, ", ")
+		if tasks == "" {
+			tasks = "-"
+		}
+
+		name := listing.Name
+		if !listing.IsActive {
+			name += " (archived)"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			listing.ID,
+			listing.Client.Name,
+			name,
+			code,
+			formatProjectBudget(listing.ProjectDetails),
+			tasks,
+			formatYesNo(appConfig.GetProjectByID(int(listing.ID)) != nil))
+	}
+
+	w.Flush()
+
+	fmt.Printf("\n%d projects\n", len(listings))
+}
+
+// formatProjectBudget formats the budget of a project in hours or in the client's currency
+func formatProjectBudget(project harvest.ProjectDetails) string {
+	if !project.HasBudget() {
+		return "-"
+	}
+
+	var budget string
+	if project.IsHourBudget() {
+		budget = fmt.Sprintf("%.2fh", *project.Budget)
+	} else if project.Client.Currency != "" {
+		budget = fmt.Sprintf("%.2f %s", *project.Budget, project.Client.Currency)
+	} else {
+		budget = formatAmount(*project.Budget)
+	}
+
+	if project.BudgetIsMonthly {
+		budget += " per month"
+	}
+	return budget
+}
+
+// findClient finds a client by ID, exact name or case-insensitive name substring
+func findClient(clients []harvest.ClientDetails, query string) *harvest.ClientDetails {
+	if id, err := strconv.ParseInt(query, 10, 64); err == nil {
+		for i := range clients {
+			if clients[i].ID == id {
+				return &clients[i]
+			}
+		}
+	}
+
+	var match *harvest.ClientDetails
+	for i := range clients {
+		if strings.EqualFold(clients[i].Name, query) {
+			return &clients[i]
+		}
+		if match == nil && strings.Contains(strings.ToLower(clients[i].Name), strings.ToLower(query)) {
+			match = &clients[i]
+		}
+	}
+	return match
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// reportKinds lists the supported report kinds
+var reportKinds = []string{"clients", "projects", "tasks", "team", "uninvoiced"}
+
+// ReportCmd returns the report command
+func ReportCmd() *cobra.Command {
+	var from, to string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:       "report <clients|projects|tasks|team|uninvoiced>",
+		Short:     "Show Harvest reports",
+		ValidArgs: reportKinds,
+		Long: `Show time and uninvoiced reports calculated by Harvest.
+Example: h report projects --from 2024-01-01 --to 2024-03-31
+
+Available reports:
+  clients     Hours and billable amounts per client
+  projects    Hours and billable amounts per project
+  tasks       Hours and billable amounts per task
+  team        Hours and billable amounts per user
+  uninvoiced  Uninvoiced hours, expenses and amounts per project
+
+By default, reports cover the current month up to today.
+Use --json flag to print the raw report rows as JSON.`,
+		Args: cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			// Default to the current month up to today
+			now := time.Now()
+			fromDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+			toDate := now
+
+			var err error
+			if from != "" {
+				fromDate, err = time.Parse("2006-01-02", from)
+				if err != nil {
+					log.Fatalf("Invalid from date. Please use YYYY-MM-DD format: %v", err)
+				}
+			}
+			if to != "" {
+				toDate, err = time.Parse("2006-01-02", to)
+				if err != nil {
+					log.Fatalf("Invalid to date. Please use YYYY-MM-DD format: %v", err)
+				}
+			}
+			if toDate.Before(fromDate) {
+				log.Fatalf("The to date must not be before the from date")
+			}
+
+			handleReport(client, args[0], fromDate, toDate, jsonOutput)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringVar(&from, "from", "", "Start date in YYYY-MM-DD format (default: first day of the current month)")
+	cmd.Flags().StringVar(&to, "to", "", "End date in YYYY-MM-DD format (default: today)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the report as JSON")
+
+	return cmd
+}
+
+// handleReport fetches and displays a report
+func handleReport(client *harvest.Client, kind string, from, to time.Time, jsonOutput bool) {
+	if !jsonOutput {
+		fmt.Printf("Fetching %s report from %s to %s...\n", kind, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	if kind == "uninvoiced" {
+		results, err := client.GetUninvoicedReport(from, to)
+		if err != nil {
+			log.Fatalf("Failed to get uninvoiced report: %v", err)
+		}
+
+		if jsonOutput {
+			printJSON(results)
+			return
+		}
+		displayUninvoicedReport(results)
+		return
+	}
+
+	var results []harvest.TimeReportResult
+	var err error
+	switch kind {
+	case "clients":
+		results, err = client.GetClientTimeReport(from, to)
+	case "projects":
+		results, err = client.GetProjectTimeReport(from, to)
+	case "tasks":
+		results, err = client.GetTaskTimeReport(from, to)
+	case "team":
+		results, err = client.GetTeamTimeReport(from, to)
+	}
+	if err != nil {
+		log.Fatalf("Failed to get %s report: %v", kind, err)
+	}
+
+	if jsonOutput {
+		printJSON(results)
+		return
+	}
+	displayTimeReport(kind, results)
+}
+
+// displayTimeReport prints a client, project, task or team time report as a table
+func displayTimeReport(kind string, results []harvest.TimeReportResult) {
+	if len(results) == 0 {
+		fmt.Println("No time tracked in this period")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	// Print table header
+	switch kind {
+	case "clients":
+		fmt.Fprintln(w, "Client\tHours\tBillable Hours\tBillable Amount")
+		fmt.Fprintln(w, "------\t-----\t--------------\t---------------")
+	case "projects":
+		fmt.Fprintln(w, "Client\tProject\tHours\tBillable Hours\tBillable Amount")
+		fmt.Fprintln(w, "------\t-------\t-----\t--------------\t---------------")
+	case "tasks":
+		fmt.Fprintln(w, "Task\tHours\tBillable Hours\tBillable Amount")
+		fmt.Fprintln(w, "----\t-----\t--------------\t---------------")
+	case "team":
+		fmt.Fprintln(w, "User\tHours\tBillable Hours\tBillable Amount")
+		fmt.Fprintln(w, "----\t-----\t--------------\t---------------")
+	}
+
+	var totalHours, billableHours float64
+	amountByCurrency := make(map[string]float64)
+
+	for _, result := range results {
+		var name string
+		switch kind {
+		case "clients":
+			name = result.ClientName
+		case "projects":
+			name = fmt.Sprintf("%s\t%s", result.ClientName, result.ProjectName)
+		case "tasks":
+			name = result.TaskName
+		case "team":
+			name = result.UserName
+			if result.IsContractor {
+				name += " (contractor)"
+			}
+		}
+
+		fmt.Fprintf(w, "%s\t%.2f\t%.2f\t%.2f %s\n",
+			name,
+			result.TotalHours,
+			result.BillableHours,
+			result.BillableAmount,
+			result.Currency)
+
+		totalHours += result.TotalHours
+		billableHours += result.BillableHours
+		amountByCurrency[result.Currency] += result.BillableAmount
+	}
+
+	// Print totals, with amounts in different currencies listed separately
+	totalLabel := "TOTAL"
+	if kind == "projects" {
+		totalLabel = "TOTAL\t"
+	}
+	fmt.Fprintf(w, "%s\t%.2f\t%.2f\t%s\n", totalLabel, totalHours, billableHours, formatCurrencyAmounts(amountByCurrency))
+
+	w.Flush()
+}
+
+// formatCurrencyAmounts formats amounts per currency, e.g. "120.00 EUR, 80.00 USD"
+func formatCurrencyAmounts(amountByCurrency map[string]float64) string {
+	var currencies []string
+	for currency := range amountByCurrency {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	var amounts []string
+	for _, currency := range currencies {
+		amounts = append(amounts, fmt.Sprintf("%.2f %s", amountByCurrency[currency], currency))
+	}
+	return strings.Join(amounts, ", ")
+}
+
+// displayUninvoicedReport prints the uninvoiced report as a table
+func displayUninvoicedReport(results []harvest.UninvoicedReportResult) {
+	if len(results) == 0 {
+		fmt.Println("Nothing uninvoiced in this period")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Client\tProject\tHours\tUninvoiced Hours\tUninvoiced Expenses\tUninvoiced Amount")
+	fmt.Fprintln(w, "------\t-------\t-----\t----------------\t-------------------\t-----------------")
+
+	var totalHours, uninvoicedHours float64
+	expensesByCurrency := make(map[string]float64)
+	amountByCurrency := make(map[string]float64)
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\t%s\t%.2f\t%.2f\t%.2f %s\t%.2f %s\n",
+			result.ClientName,
+			result.ProjectName,
+			result.TotalHours,
+			result.UninvoicedHours,
+			result.UninvoicedExpenses,
+			result.Currency,
+			result.UninvoicedAmount,
+			result.Currency)
+
+		totalHours += result.TotalHours
+		uninvoicedHours += result.UninvoicedHours
+		expensesByCurrency[result.Currency] += result.UninvoicedExpenses
+		amountByCurrency[result.Currency] += result.UninvoicedAmount
+	}
+
+	// Amounts in different currencies are listed separately
+	fmt.Fprintf(w, "TOTAL\t\t%.2f\t%.2f\t%s\t%s\n",
+		totalHours,
+		uninvoicedHours,
+		formatCurrencyAmounts(expensesByCurrency),
+		formatCurrencyAmounts(amountByCurrency))
+
+	w.Flush()
+}
+
+// printJSON prints a value as indented JSON
+func printJSON(value interface{}) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to format JSON: %v", err)
+	}
+	fmt.Println(string(data))
+}
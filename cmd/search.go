@@ -0,0 +1,391 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+// Search modes
+const (
+	searchSubstring = "substring"
+	searchRegex     = "regex"
+	searchFuzzy     = "fuzzy"
+)
+
+// searchGroupings lists the supported ways to group search hits
+var searchGroupings = []string{"match", "project", "task", "month"}
+
+// SearchHit represents a time entry matching a search query
+type SearchHit struct {
+	Entry harvest.TimeEntry
+	Field string // Field containing the match: "notes", "project" or "task"
+	Match string // Matched text
+}
+
+// SearchGroup represents the totals of search hits sharing a group key
+type SearchGroup struct {
+	Key     string
+	Entries int
+	Hours   float64
+	First   string
+	Last    string
+}
+
+// matchFunc returns the matched text if the text matches a query
+type matchFunc func(text string) (string, bool)
+
+// SearchCmd returns the search command
+func SearchCmd() *cobra.Command {
+	var from, to, mode, groupBy string
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search time entries",
+		Long: `Search the notes, project and task names of time entries in a date range.
+Example: h search ABC-123 --from 2024-01-01
+
+Matching modes (-m flag):
+  substring  Case-insensitive substring (default)
+  regex      Regular expression, e.g. "ABC-\d+" (case-sensitive unless (?i) is used)
+  fuzzy      Case-insensitive characters in order, e.g. "lgnfx" matches "login fix"
+
+Hits are totalled per group (-g flag): the matched text (default), project, task or month.
+With a regular expression, the first capture group is used as the matched text if present.
+By default, searches from the start of the current year (based on year_start_date in config) to today.`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			match, err := newMatchFunc(args[0], mode)
+			if err != nil {
+				log.Fatalf("Invalid search query: %v", err)
+			}
+
+			if !containsString(searchGroupings, groupBy) {
+				log.Fatalf("Invalid grouping: %s, expected one of %s", groupBy, strings.Join(searchGroupings, ", "))
+			}
+
+			// Default to the start of the current year up to today
+			if from == "" {
+				startMonth, startDay, err := appConfig.GetYearStartDate()
+				if err != nil {
+					log.Fatalf("Failed to get year start date: %v", err)
+				}
+				from = fiscalYearStart(time.Now(), startMonth, startDay).Format("2006-01-02")
+			}
+			if to == "" {
+				to = time.Now().Format("2006-01-02")
+			}
+			for _, value := range []string{from, to} {
+				if _, err := time.Parse("2006-01-02", value); err != nil {
+					log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+				}
+			}
+
+			handleSearch(client, args[0], match, from, to, groupBy)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringVar(&from, "from", "", "Start date in YYYY-MM-DD format (default: start of the current year)")
+	cmd.Flags().StringVar(&to, "to", "", "End date in YYYY-MM-DD format (default: today)")
+	cmd.Flags().StringVarP(&mode, "mode", "m", searchSubstring, "Matching mode: substring, regex or fuzzy")
+	cmd.Flags().StringVarP(&groupBy, "group-by", "g", "match", "Group totals by match, project, task or month")
+
+	return cmd
+}
+
+// handleSearch searches the time entries between two dates and prints the hits with group totals
+func handleSearch(client *harvest.Client, query string, match matchFunc, from, to, groupBy string) {
+	fmt.Printf("Fetching time entries from %s to %s...\n", from, to)
+	timeEntries, err := client.GetAllTimeEntries(map[string]string{
+		"from": from,
+		"to":   to,
+	})
+	if err != nil {
+		log.Fatalf("Failed to get time entries: %v", err)
+	}
+
+	hits := searchTimeEntries(timeEntries, match)
+	if len(hits) == 0 {
+		fmt.Printf("No time entries matching '%s' found in %d entries\n", query, len(timeEntries))
+		return
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Entry.SpentDate < hits[j].Entry.SpentDate
+	})
+
+	fmt.Printf("\nTime Entries matching '%s' (%d of %d entries):\n", query, len(hits), len(timeEntries))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Date\tID\tProject | Task\tNotes\tDuration\tMatch")
+	fmt.Fprintln(w, "----\t----\t--------------\t-----\t--------\t-----")
+
+	var totalHours float64
+	for _, hit := range hits {
+		hours, minutes := convertDecimalToHoursMinutes(hit.Entry.Hours)
+
+		// Truncate notes if too long
+		notes := hit.Entry.Notes
+		if len(notes) > 40 {
+			notes = notes[:37] + "..."
+		}
+
+		matchInfo := hit.Match
+		if hit.Field != "notes" {
+			matchInfo = fmt.Sprintf("%s (%s)", hit.Match, hit.Field)
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%s | %s\t%s\t%02d:%02d\t%s\n",
+			hit.Entry.SpentDate,
+			hit.Entry.ID,
+			hit.Entry.Project.Name,
+			hit.Entry.Task.Name,
+			notes,
+			hours,
+			minutes,
+			matchInfo)
+
+		totalHours += hit.Entry.Hours
+	}
+
+	w.Flush()
+
+	// Display totals per group
+	groups := groupSearchHits(hits, groupBy)
+
+	fmt.Printf("\nTotals by %s:\n", groupBy)
+	fmt.Println("------------------------------------")
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Group\tEntries\tDuration\tFirst\tLast")
+	fmt.Fprintln(tw, "-----\t-------\t--------\t-----\t----")
+
+	for _, group := range groups {
+		hours, minutes := convertDecimalToHoursMinutes(group.Hours)
+		fmt.Fprintf(tw, "%s\t%d\t%02d:%02d\t%s\t%s\n",
+			group.Key,
+			group.Entries,
+			hours,
+			minutes,
+			group.First,
+			group.Last)
+	}
+
+	totalHoursInt, totalMinutes := convertDecimalToHoursMinutes(totalHours)
+	fmt.Fprintf(tw, "TOTAL\t%d\t%02d:%02d\t\t\n", len(hits), totalHoursInt, totalMinutes)
+
+	tw.Flush()
+}
+
+// searchTimeEntries returns the time entries whose notes, project or task match
+func searchTimeEntries(timeEntries []harvest.TimeEntry, match matchFunc) []SearchHit {
+	var hits []SearchHit
+	for _, entry := range timeEntries {
+		fields := []struct {
+			name  string
+			value string
+		}{
+			{"notes", entry.Notes},
+			{"project", entry.Project.Name},
+			{"task", entry.Task.Name},
+		}
+
+		for _, field := range fields {
+			if matched, ok := match(field.value); ok {
+				hits = append(hits, SearchHit{
+					Entry: entry,
+					Field: field.name,
+					Match: matched,
+				})
+				break
+			}
+		}
+	}
+
+	return hits
+}
+
+// groupSearchHits totals the hits per group, sorted by hours
+func groupSearchHits(hits []SearchHit, groupBy string) []SearchGroup {
+	groupsByKey := make(map[string]*SearchGroup)
+	var keys []string
+
+	for _, hit := range hits {
+		var key string
+		switch groupBy {
+		case "project":
+			key = hit.Entry.Project.Name
+		case "task":
+			key = hit.Entry.Task.Name
+		case "month":
+			key = hit.Entry.SpentDate[:7]
+		default:
+			key = hit.Match
+		}
+
+		group, exists := groupsByKey[key]
+		if !exists {
+			group = &SearchGroup{Key: key, First: hit.Entry.SpentDate}
+			groupsByKey[key] = group
+			keys = append(keys, key)
+		}
+
+		group.Entries++
+		group.Hours += hit.Entry.Hours
+		if hit.Entry.SpentDate < group.First {
+			group.First = hit.Entry.SpentDate
+		}
+		if hit.Entry.SpentDate > group.Last {
+			group.Last = hit.Entry.SpentDate
+		}
+	}
+
+	groups := make([]SearchGroup, len(keys))
+	for i, key := range keys {
+		groups[i] = *groupsByKey[key]
+	}
+
+	// Months are listed in order, other groups by hours spent
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groupBy == "month" {
+			return groups[i].Key < groups[j].Key
+		}
+		return groups[i].Hours > groups[j].Hours
+	})
+
+	return groups
+}
+
+// newMatchFunc builds the matcher for a query and matching mode
+func newMatchFunc(query, mode string) (matchFunc, error) {
+	if query == "" {
+		return nil, fmt.Errorf("query cannot be blank")
+	}
+
+	switch mode {
+	case searchSubstring:
+		lowerQuery := strings.ToLower(query)
+		return func(text string) (string, bool) {
+			index := indexFold(text, lowerQuery)
+			if index < 0 {
+				return "", false
+			}
+			return text[index : index+len(lowerQuery)], true
+		}, nil
+	case searchRegex:
+		re, err := regexp.Compile(query)
+		if err != nil {
+			return nil, err
+		}
+		return func(text string) (string, bool) {
+			submatches := re.FindStringSubmatch(text)
+			if submatches == nil {
+				return "", false
+			}
+			if len(submatches) > 1 && submatches[1] != "" {
+				return submatches[1], true
+			}
+			return submatches[0], true
+		}, nil
+	case searchFuzzy:
+		return func(text string) (string, bool) {
+			return fuzzyMatch(text, query)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown mode: %s, expected %s, %s or %s", mode, searchSubstring, searchRegex, searchFuzzy)
+	}
+}
+
+// indexFold returns the byte index of the first case-insensitive occurrence of a lowercase query in text, or -1
+func indexFold(text, lowerQuery string) int {
+	for i := range text {
+		if len(text)-i < len(lowerQuery) {
+			break
+		}
+		if strings.EqualFold(text[i:i+len(lowerQuery)], lowerQuery) {
+			return i
+		}
+	}
+	return -1
+}
+
+// fuzzyMatch checks whether the characters of the query appear in order in the text, ignoring case
+// and whitespace in the query, and returns the shortest span of text containing them
+func fuzzyMatch(text, query string) (string, bool) {
+	var needle []rune
+	for _, r := range strings.ToLower(query) {
+		if !unicode.IsSpace(r) {
+			needle = append(needle, r)
+		}
+	}
+	if len(needle) == 0 {
+		return "", false
+	}
+
+	best := ""
+	found := false
+
+	// Try every occurrence of the first character as the start of the span
+	for start, r := range text {
+		if unicode.ToLower(r) != needle[0] {
+			continue
+		}
+
+		matched := 1
+		end := start + utf8.RuneLen(r)
+		for offset, next := range text[end:] {
+			if matched == len(needle) {
+				break
+			}
+			if unicode.ToLower(next) == needle[matched] {
+				matched++
+				end = start + utf8.RuneLen(r) + offset + utf8.RuneLen(next)
+			}
+		}
+
+		if matched < len(needle) {
+			break // Later starts cannot match either
+		}
+
+		span := text[start:end]
+		if !found || len(span) < len(best) {
+			best = span
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// containsString reports whether a slice contains a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// SubmitCmd returns the submit command
+func SubmitCmd() *cobra.Command {
+	var week, date string
+
+	cmd := &cobra.Command{
+		Use:   "submit",
+		Short: "Review a week and submit it for approval",
+		Args:  weekArgs,
+		Long: `Review the time entries of a week before submitting the timesheet for approval.
+Shows the hours logged per day against your work schedule, the approval status of
+the entries and any days that are missing time.
+
+The Harvest API does not support submitting timesheets, so the final submission
+happens on the Harvest week view, which is linked at the end of the review.
+Weeks start on the week start day of the Harvest account.
+Use --week=last or --week=next to review the week before or after the current one,
+or --week=YYYY-MM-DD to review the week containing a date. Use -d flag to select the week
+containing a specific date (YYYY-MM-DD format), --week is then relative to that week.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			targetDate, err := resolveWeekDate(week, date)
+			if err != nil {
+				log.Fatalf("Failed to select the week: %v", err)
+			}
+
+			handleSubmitWeek(client, targetDate)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringVarP(&week, "week", "w", "this", "Week to review: this, last, next or a date in YYYY-MM-DD format")
+	cmd.Flags().Lookup("week").NoOptDefVal = "this"
+	cmd.Flags().StringVarP(&date, "date", "d", "", "Date in YYYY-MM-DD format (default: today)")
+
+	return cmd
+}
+
+// handleSubmitWeek reviews the week containing the target date and links to its approval page
+func handleSubmitWeek(client *harvest.Client, targetDate time.Time) {
+	company, err := client.GetCompany()
+	if err != nil {
+		log.Fatalf("Failed to get company: %v", err)
+	}
+	if !company.ApprovalFeature {
+		log.Fatalf("Timesheet approval is not enabled for %s", company.Name)
+	}
+
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		log.Fatalf("Failed to get current user: %v", err)
+	}
+
+	// Weeks start on the week start day of the account, like the Harvest week view
+	startDate := startOfWeek(targetDate, company.WeekStart())
+	endDate := startDate.AddDate(0, 0, 6)
+
+	params := map[string]string{
+		"from":    startDate.Format("2006-01-02"),
+		"to":      endDate.Format("2006-01-02"),
+		"user_id": strconv.FormatInt(user.ID, 10),
+	}
+
+	fmt.Printf("Fetching time entries for week of %s...\n", startDate.Format("Jan 2, 2006"))
+	timeEntries, err := client.GetAllTimeEntries(params)
+	if err != nil {
+		log.Fatalf("Failed to get time entries: %v", err)
+	}
+
+	// Aggregate hours by day and count entries by approval status
+	hoursByDate := make(map[string]float64)
+	statusCounts := make(map[string]int)
+	for _, entry := range timeEntries {
+		hoursByDate[entry.SpentDate] += entry.Hours
+
+		status := entry.ApprovalStatus
+		if status == "" {
+			status = "unsubmitted"
+		}
+		statusCounts[status]++
+	}
+
+	// Display the review
+	fmt.Printf("\nTimesheet Review (%s to %s):\n", startDate.Format("Jan 2"), endDate.Format("Jan 2, 2006"))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Day\tLogged\tTarget\t")
+	fmt.Fprintln(w, "---\t------\t------\t")
+
+	cal := loadCalendar()
+	var totalHours, totalTarget float64
+	var missingDays []string
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		logged := hoursByDate[day.Format("2006-01-02")]
+		target := cal.ExpectedHours(day)
+		totalHours += logged
+		totalTarget += target
+
+		marker := ""
+		if logged < target {
+			marker = "missing time"
+			missingDays = append(missingDays, day.Format("Mon Jan 2"))
+		}
+
+		loggedHours, loggedMinutes := convertDecimalToHoursMinutes(logged)
+		targetHours, targetMinutes := convertDecimalToHoursMinutes(target)
+		fmt.Fprintf(w, "%s\t%02d:%02d\t%02d:%02d\t%s\n",
+			day.Format("Mon Jan 2"),
+			loggedHours, loggedMinutes,
+			targetHours, targetMinutes,
+			marker)
+	}
+
+	totalLoggedHours, totalLoggedMinutes := convertDecimalToHoursMinutes(totalHours)
+	totalTargetHours, totalTargetMinutes := convertDecimalToHoursMinutes(totalTarget)
+	fmt.Fprintf(w, "TOTAL\t%02d:%02d\t%02d:%02d\t\n",
+		totalLoggedHours, totalLoggedMinutes,
+		totalTargetHours, totalTargetMinutes)
+
+	w.Flush()
+
+	fmt.Println("\nApproval Status:")
+	for _, status := range []string{"unsubmitted", "submitted", "approved"} {
+		fmt.Printf("- %s: %d entries\n", status, statusCounts[status])
+	}
+
+	if len(missingDays) > 0 {
+		fmt.Printf("\nWarning: %d days are below target: %s\n", len(missingDays), strings.Join(missingDays, ", "))
+	}
+
+	if statusCounts["unsubmitted"] == 0 && len(timeEntries) > 0 {
+		fmt.Println("\nThis week has already been submitted.")
+		return
+	}
+
+	fmt.Println("\nSubmit the week for approval in Harvest:")
+	fmt.Printf("%s/time/week/%s/%d\n",
+		strings.TrimSuffix(company.BaseURI, "/"),
+		startDate.Format("2006/01/02"),
+		user.ID)
+}
+
+// entryStatus returns the approval and lock state of a time entry for display
+func entryStatus(entry harvest.TimeEntry) string {
+	status := entry.ApprovalStatus
+	if status == "" || status == "unsubmitted" {
+		status = "-"
+	}
+
+	if entry.IsLocked {
+		status += " (locked)"
+	}
+
+	return status
+}
+
+// checkEntryUnlocked returns an error explaining why a locked time entry cannot be changed
+func checkEntryUnlocked(entry *harvest.TimeEntry, action string) error {
+	if !entry.IsLocked {
+		return nil
+	}
+
+	reason := entry.LockedReason
+	if reason == "" {
+		reason = "the timesheet has been submitted or approved"
+	}
+
+	return fmt.Errorf("time entry %d is locked and cannot be %s: %s", entry.ID, action, reason)
+}
@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"harvest-cli/pkg/journal"
+	"harvest-cli/pkg/queue"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// SyncCmd returns the sync command
+func SyncCmd() *cobra.Command {
+	var list, force bool
+	var discard int64
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Send queued offline changes to Harvest",
+		Long: `Replay the creates, updates and deletes that were queued while Harvest was unreachable,
+in the order they were made.
+If an entry was changed in Harvest after it was queued, you are asked whether to apply
+the queued change anyway, keep it in the queue or discard it.
+Use -l flag to list the queue without sending anything.
+Use --discard flag with a queue ID to drop a queued change.
+Use --force flag to apply queued changes over conflicting changes without asking.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			q := loadQueue()
+
+			if discard != 0 {
+				if err := q.Remove(discard); err != nil {
+					log.Fatalf("Failed to discard queued change: %v", err)
+				}
+				fmt.Printf("Discarded queued change #%d\n", discard)
+				return
+			}
+
+			if list {
+				displayQueue(q)
+				return
+			}
+
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			handleSync(client, q, force)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().BoolVarP(&list, "list", "l", false, "List the queued changes without sending them")
+	cmd.Flags().BoolVar(&force, "force", false, "Apply queued changes over conflicting changes without asking")
+	cmd.Flags().Int64Var(&discard, "discard", 0, "Drop the queued change with this queue ID")
+
+	return cmd
+}
+
+// loadQueue loads the offline queue from the data directory
+func loadQueue() *queue.Queue {
+	dataDir, err := appConfig.GetDataDir()
+	if err != nil {
+		log.Fatalf("Failed to get data directory: %v", err)
+	}
+
+	q, err := queue.Load(dataDir)
+	if err != nil {
+		log.Fatalf("Failed to load offline queue: %v", err)
+	}
+	return q
+}
+
+// queueOperation stores an operation that failed because Harvest is unreachable so it can be sent by "h sync"
+func queueOperation(action string, entryID int64, request, snapshot *harvest.TimeEntry, cause error) {
+	queueItem(queue.Item{
+		Action:   action,
+		EntryID:  entryID,
+		Request:  request,
+		Snapshot: snapshot,
+	}, cause)
+}
+
+// queueFieldUpdate stores the changed fields of an entry that couldn't be fetched because Harvest
+// is unreachable, so they can be sent by "h sync"
+func queueFieldUpdate(entryID int64, update *harvest.TimeEntryUpdate, cause error) {
+	queueItem(queue.Item{
+		Action:  queue.ActionUpdate,
+		EntryID: entryID,
+		Update:  update,
+	}, cause)
+}
+
+// queueItem adds an operation of the current account to the offline queue
+func queueItem(item queue.Item, cause error) {
+	item.AccountID = appConfig.HarvestAPI.AccountID
+	item, err := loadQueue().Add(item)
+	if err != nil {
+		log.Fatalf("Harvest is unreachable (%v) and the change could not be queued: %v", cause, err)
+	}
+
+	fmt.Printf("\nHarvest is unreachable: %v\n", cause)
+	fmt.Printf("Queued the %s as pending change #%d. Run \"h sync\" when you are back online.\n", describeQueueItem(item), item.ID)
+}
+
+// handleSync replays the queued operations in order
+func handleSync(client *harvest.Client, q *queue.Queue, force bool) {
+	items := q.Items(appConfig.HarvestAPI.AccountID)
+	if len(items) == 0 {
+		fmt.Println("Nothing to sync")
+		return
+	}
+
+	fmt.Printf("Syncing %d queued changes...\n", len(items))
+
+	// Later changes of an entry whose earlier change stays queued are kept too, so that
+	// the changes of each entry are applied in order
+	blocked := make(map[int64]bool)
+
+	var synced, kept int
+	for i, item := range items {
+		fmt.Printf("\n#%d %s\n", item.ID, describeQueueItem(item))
+
+		if item.EntryID != 0 && blocked[item.EntryID] {
+			fmt.Printf("Kept in queue after the earlier change of time entry %d\n", item.EntryID)
+			kept++
+			continue
+		}
+
+		done, err := syncItem(client, item, force)
+		if harvest.IsNetworkError(err) {
+			fmt.Printf("Harvest is still unreachable: %v\n", err)
+			kept += len(items) - i
+			break
+		}
+		if err != nil {
+			fmt.Printf("Failed, kept in queue: %v\n", err)
+		}
+		if err != nil || !done {
+			blocked[item.EntryID] = true
+			kept++
+			continue
+		}
+
+		if err := q.Remove(item.ID); err != nil {
+			log.Fatalf("Failed to update offline queue: %v", err)
+		}
+		synced++
+	}
+
+	// Summary
+	fmt.Println("\nSync Summary:")
+	fmt.Println("-----------------------------------")
+	fmt.Printf("Sent: %d\n", synced)
+	fmt.Printf("Still queued: %d\n", kept)
+	fmt.Println("-----------------------------------")
+}
+
+// syncItem sends a queued operation, returning false if it should stay in the queue
+func syncItem(client *harvest.Client, item queue.Item, force bool) (bool, error) {
+	switch item.Action {
+	case queue.ActionCreate:
+		created, err := client.CreateTimeEntry(item.Request)
+		if err != nil {
+			return false, err
+		}
+		recordOperation(journal.ActionCreate, nil, created)
+		fmt.Printf("Created time entry %d\n", created.ID)
+		return true, nil
+	case queue.ActionUpdate, queue.ActionDelete:
+		current, err := client.GetTimeEntry(item.EntryID)
+		if harvest.IsNotFound(err) {
+			if item.Action == queue.ActionDelete {
+				fmt.Printf("Time entry %d was already deleted\n", item.EntryID)
+				return true, nil
+			}
+			fmt.Printf("Time entry %d no longer exists, dropping the update\n", item.EntryID)
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		// Detect changes made in Harvest after the operation was queued
+		if item.Snapshot != nil && !current.UpdatedAt.Equal(item.Snapshot.UpdatedAt) && !force {
+			apply, discard := resolveSyncConflict(item, current)
+			if discard {
+				fmt.Println("Discarded queued change")
+				return true, nil
+			}
+			if !apply {
+				fmt.Println("Kept in queue")
+				return false, nil
+			}
+		}
+
+		if item.Action == queue.ActionDelete {
+			if err := checkEntryUnlocked(current, "deleted"); err != nil {
+				return false, err
+			}
+			if err := client.DeleteTimeEntry(current.ID); err != nil {
+				return false, err
+			}
+			recordOperation(journal.ActionDelete, current, nil)
+			fmt.Printf("Deleted time entry %d\n", current.ID)
+			return true, nil
+		}
+
+		if err := checkEntryUnlocked(current, "updated"); err != nil {
+			return false, err
+		}
+
+		// Only the fields changed while offline are sent, other changes made in Harvest are kept
+		update := item.Update
+		if update == nil {
+			base := item.Snapshot
+			if base == nil {
+				base = current
+			}
+			update = harvest.DiffTimeEntry(base, item.Request)
+		}
+		if update.IsEmpty() {
+			fmt.Printf("Time entry %d has nothing to update\n", current.ID)
+			return true, nil
+		}
+
+		updated, err := client.UpdateTimeEntry(current.ID, update)
+		if err != nil {
+			return false, err
+		}
+		recordOperation(journal.ActionUpdate, current, updated)
+		fmt.Printf("Updated time entry %d\n", updated.ID)
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown queued operation: %s", item.Action)
+	}
+}
+
+// resolveSyncConflict asks how to handle an entry that changed after the operation was queued
+func resolveSyncConflict(item queue.Item, current *harvest.TimeEntry) (apply bool, discard bool) {
+	fmt.Printf("Conflict: time entry %d was changed in Harvest after this change was queued\n", current.ID)
+	fmt.Printf("  Queued against: %s\n", describeEntry(item.Snapshot))
+	fmt.Printf("  Now in Harvest: %s\n", describeEntry(current))
+
+	prompt := promptui.Select{
+		Label: "What would you like to do?",
+		Items: []string{"Apply the queued change anyway", "Keep it in the queue", "Discard the queued change"},
+	}
+
+	index, _, err := prompt.Run()
+	if err != nil {
+		log.Fatalf("Prompt failed: %v", err)
+	}
+
+	return index == 0, index == 2
+}
+
+// displayQueue lists the queued operations of the current account
+func displayQueue(q *queue.Queue) {
+	items := q.Items(appConfig.HarvestAPI.AccountID)
+	if len(items) == 0 {
+		fmt.Println("No queued changes")
+		return
+	}
+
+	fmt.Printf("Queued changes (%s):\n\n", q.Path())
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tQueued At\tAction\tEntry ID\tDetails")
+	fmt.Fprintln(w, "-\t---------\t------\t--------\t-------")
+
+	for _, item := range items {
+		entryID := "-"
+		if item.EntryID != 0 {
+			entryID = fmt.Sprintf("%d", item.EntryID)
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
+			item.ID,
+			item.Time.Local().Format("2006-01-02 15:04"),
+			item.Action,
+			entryID,
+			describeQueuedEntry(item))
+	}
+
+	w.Flush()
+}
+
+// describeQueueItem returns a short description of a queued operation
+func describeQueueItem(item queue.Item) string {
+	if item.Action == queue.ActionCreate {
+		return fmt.Sprintf("create of a time entry on %s", item.Request.SpentDate)
+	}
+	return fmt.Sprintf("%s of time entry %d", item.Action, item.EntryID)
+}
+
+// describeQueuedEntry describes the entry a queued operation applies to
+func describeQueuedEntry(item queue.Item) string {
+	if item.Update != nil {
+		return describeFieldUpdate(item.Update)
+	}
+
+	entry := item.Request
+	if entry == nil {
+		entry = item.Snapshot
+	}
+	if entry == nil {
+		return "-"
+	}
+
+	hours, minutes := convertDecimalToHoursMinutes(entry.Hours)
+	description := fmt.Sprintf("%s (%02d:%02d)", entry.SpentDate, hours, minutes)
+	if entry.Notes != "" {
+		description += " - " + entry.Notes
+	}
+	return description
+}
+
+// describeFieldUpdate lists the fields changed by an update, e.g. "hours: 03:00, notes: (cleared)"
+func describeFieldUpdate(update *harvest.TimeEntryUpdate) string {
+	var changes []string
+	if update.SpentDate != nil {
+		changes = append(changes, "date: "+*update.SpentDate)
+	}
+	if update.ProjectID != nil && update.TaskID != nil {
+		changes = append(changes, fmt.Sprintf("project: %d, task: %d", *update.ProjectID, *update.TaskID))
+	}
+	if update.Hours != nil {
+		hours, minutes := convertDecimalToHoursMinutes(*update.Hours)
+		changes = append(changes, fmt.Sprintf("hours: %02d:%02d", hours, minutes))
+	}
+	if update.Notes != nil {
+		notes := *update.Notes
+		if notes == "" {
+			notes = "(cleared)"
+		}
+		changes = append(changes, "notes: "+notes)
+	}
+	return strings.Join(changes, ", ")
+}
+
+// pendingChanges returns the status of entries with queued updates or deletes on a date,
+// and the queued creates as time entries without an ID
+func pendingChanges(date string) (map[int64]string, []harvest.TimeEntry) {
+	status := make(map[int64]string)
+	var created []harvest.TimeEntry
+
+	for _, item := range loadQueue().Items(appConfig.HarvestAPI.AccountID) {
+		switch item.Action {
+		case queue.ActionCreate:
+			if item.Request == nil || item.Request.SpentDate != date {
+				continue
+			}
+			entry := *item.Request
+			entry.Project.ID = int64(entry.ProjectID)
+			entry.Task.ID = int64(entry.TaskID)
+			for _, project := range appConfig.Projects {
+				if project.ID != entry.ProjectID {
+					continue
+				}
+				entry.Project.Name = project.Name
+				for _, task := range project.Tasks {
+					if task.ID == entry.TaskID {
+						entry.Task.Name = task.Name
+					}
+				}
+			}
+			created = append(created, entry)
+			status[0] = "pending create"
+		default:
+			status[item.EntryID] = "pending " + item.Action
+		}
+	}
+
+	return status, created
+}
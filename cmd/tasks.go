@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// TasksCmd returns the tasks command
+func TasksCmd() *cobra.Command {
+	var includeInactive, jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "tasks [project]",
+		Short: "List tasks in Harvest",
+		Long: `List active tasks with their billable default and hourly rate.
+Example: h tasks "Project A"
+
+With a project name (case-insensitive substring), code or ID, lists the tasks
+assigned to the matching projects with their billable flag and hourly rate.
+Use --all flag to include archived tasks.
+Use --json flag to print the tasks as JSON.`,
+		Args: cobra.MaximumNArgs(1),
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			params := make(map[string]string)
+			if !includeInactive {
+				params["is_active"] = "true"
+			}
+
+			if len(args) > 0 {
+				handleProjectTasks(client, args[0], params, jsonOutput)
+			} else {
+				handleTasks(client, params, jsonOutput)
+			}
+		},
+	}
+
+	// Define flags
+	cmd.Flags().BoolVar(&includeInactive, "all", false, "Include archived tasks")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the tasks as JSON")
+
+	return cmd
+}
+
+// handleTasks lists all tasks of the account
+func handleTasks(client *harvest.Client, params map[string]string, jsonOutput bool) {
+	if !jsonOutput {
+		fmt.Println("Fetching tasks...")
+	}
+	tasks, err := client.ListTasks(params)
+	if err != nil {
+		log.Fatalf("Failed to get tasks: %v", err)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Name < tasks[j].Name
+	})
+
+	if jsonOutput {
+		printJSON(tasks)
+		return
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println("No tasks found")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTask\tBillable\tDefault Rate\tAdded to New Projects")
+	fmt.Fprintln(w, "----\t----\t--------\t------------\t---------------------")
+
+	for _, task := range tasks {
+		name := task.Name
+		if !task.IsActive {
+			name += " (archived)"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
+			task.ID,
+			name,
+			formatYesNo(task.BillableByDefault),
+			formatRate(task.DefaultHourlyRate),
+			formatYesNo(task.IsDefault))
+	}
+
+	w.Flush()
+}
+
+// handleProjectTasks lists the tasks assigned to the projects matching the filter
+func handleProjectTasks(client *harvest.Client, filter string, params map[string]string, jsonOutput bool) {
+	if !jsonOutput {
+		fmt.Println("Fetching task assignments...")
+	}
+	assignments, err := client.ListTaskAssignments(params)
+	if err != nil {
+		log.Fatalf("Failed to get task assignments: %v", err)
+	}
+
+	var matched []harvest.TaskAssignment
+	for _, assignment := range assignments {
+		if matchesProjectFilter(assignment.Project.ID, assignment.Project.Name, assignment.Project.Code, filter) {
+			matched = append(matched, assignment)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Project.Name != matched[j].Project.Name {
+			return matched[i].Project.Name < matched[j].Project.Name
+		}
+		return matched[i].Task.Name < matched[j].Task.Name
+	})
+
+	if jsonOutput {
+		printJSON(matched)
+		return
+	}
+
+	if len(matched) == 0 {
+		fmt.Printf("No tasks found for projects matching '%s'\n", filter)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Project (ID)\tTask ID\tTask\tBillable\tHourly Rate")
+	fmt.Fprintln(w, "------------\t-------\t----\t--------\t-----------")
+
+	for _, assignment := range matched {
+		name := assignment.Task.Name
+		if !assignment.IsActive {
+			name += " (archived)"
+		}
+
+		fmt.Fprintf(w, "%s (%d)\t%d\t%s\t%s\t%s\n",
+			assignment.Project.Name,
+			assignment.Project.ID,
+			assignment.Task.ID,
+			name,
+			formatYesNo(assignment.Billable),
+			formatRate(assignment.HourlyRate))
+	}
+
+	w.Flush()
+}
+
+// formatYesNo formats a flag for table output
+func formatYesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "-"
+}
+
+// formatRate formats an optional hourly rate in the configured currency
+func formatRate(rate *float64) string {
+	if rate == nil {
+		return "-"
+	}
+	return formatAmount(*rate)
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// TeamCmd returns the team command
+func TeamCmd() *cobra.Command {
+	var week, date string
+
+	cmd := &cobra.Command{
+		Use:   "team",
+		Short: "Show the hours of all team members",
+		Args:  weekArgs,
+		Long: `Show a matrix of active users and the hours they logged on each day of the week.
+Days without time up to today are marked, and users who haven't logged time are listed.
+Use --week=last or --week=next to show the week before or after the current one,
+or --week=YYYY-MM-DD to show the week containing a date. Use -d flag to select the week containing a
+specific date (YYYY-MM-DD format), --week is then relative to that week.
+Requires manager or administrator permissions in Harvest.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			targetDate, err := resolveWeekDate(week, date)
+			if err != nil {
+				log.Fatalf("Failed to select the week: %v", err)
+			}
+
+			handleTeamWeek(client, targetDate)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringVarP(&week, "week", "w", "this", "Week to show: this, last, next or a date in YYYY-MM-DD format")
+	cmd.Flags().Lookup("week").NoOptDefVal = "this"
+	cmd.Flags().StringVarP(&date, "date", "d", "", "Date in YYYY-MM-DD format (default: today)")
+
+	return cmd
+}
+
+// weekArgs rejects arguments, which are most likely a --week value given without "="
+func weekArgs(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected argument %q, use --week=%s to select a week", args[0], args[0])
+	}
+	return nil
+}
+
+// resolveWeekDate returns a date in the week selected by a --week value ("this", "last", "next" or a
+// date in YYYY-MM-DD format), relative to the week of the --date value or today
+func resolveWeekDate(week, date string) (time.Time, error) {
+	targetDate := time.Now()
+	if date != "" {
+		var err error
+		targetDate, err = time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			return targetDate, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+		}
+	}
+
+	switch strings.ToLower(week) {
+	case "", "this":
+		return targetDate, nil
+	case "last":
+		return targetDate.AddDate(0, 0, -7), nil
+	case "next":
+		return targetDate.AddDate(0, 0, 7), nil
+	}
+
+	weekDate, err := time.ParseInLocation("2006-01-02", week, time.Local)
+	if err != nil {
+		return weekDate, fmt.Errorf("invalid week %q, expected this, last, next or a date in YYYY-MM-DD format", week)
+	}
+	return weekDate, nil
+}
+
+// startOfWeek returns the first day of the week of a date, for weeks starting on the given weekday
+func startOfWeek(date time.Time, first time.Weekday) time.Time {
+	offset := (int(date.Weekday()) - int(first) + 7) % 7
+	start := date.AddDate(0, 0, -offset)
+	return time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// handleTeamWeek shows the users × days matrix for the week containing the target date
+func handleTeamWeek(client *harvest.Client, targetDate time.Time) {
+	company, err := client.GetCompany()
+	if err != nil {
+		log.Fatalf("Failed to get company: %v", err)
+	}
+
+	// Weeks start on the week start day of the account, like "h submit"
+	startDate := startOfWeek(targetDate, company.WeekStart())
+	endDate := startDate.AddDate(0, 0, 6)
+
+	fmt.Println("Fetching users...")
+	users, err := client.ListUsers(map[string]string{"is_active": "true"})
+	if err != nil {
+		log.Fatalf("Failed to get users: %v", err)
+	}
+
+	params := map[string]string{
+		"from": startDate.Format("2006-01-02"),
+		"to":   endDate.Format("2006-01-02"),
+	}
+
+	fmt.Printf("Fetching time entries for week of %s...\n", startDate.Format("Jan 2, 2006"))
+	timeEntries, err := client.GetAllTimeEntries(params)
+	if err != nil {
+		log.Fatalf("Failed to get time entries: %v", err)
+	}
+
+	// Aggregate hours by user and day
+	hoursByUser := make(map[int64]map[string]float64)
+	for _, entry := range timeEntries {
+		if hoursByUser[entry.User.ID] == nil {
+			hoursByUser[entry.User.ID] = make(map[string]float64)
+		}
+		hoursByUser[entry.User.ID][entry.SpentDate] += entry.Hours
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].FullName() < users[j].FullName()
+	})
+
+	// Display the matrix
+	fmt.Printf("\nTeam Week (%s to %s):\n", startDate.Format("Jan 2"), endDate.Format("Jan 2, 2006"))
+	fmt.Println("Days marked with ! have no time logged.")
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	header := []string{"User"}
+	separator := []string{"----"}
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		header = append(header, day.Format("Mon 02"))
+		separator = append(separator, "------")
+	}
+	header = append(header, "Total")
+	separator = append(separator, "-----")
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	fmt.Fprintln(w, strings.Join(separator, "\t"))
+
+	today := time.Now()
+	var missing []string
+	for _, user := range users {
+		row := []string{user.FullName()}
+		var total float64
+		var missingDays []string
+
+		for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+			hours := hoursByUser[user.ID][day.Format("2006-01-02")]
+			total += hours
+
+			isWorkday := day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+			switch {
+			case hours > 0:
+				h, m := convertDecimalToHoursMinutes(hours)
+				row = append(row, fmt.Sprintf("%02d:%02d", h, m))
+			case isWorkday && !day.After(today):
+				row = append(row, "!")
+				missingDays = append(missingDays, day.Format("Mon"))
+			default:
+				row = append(row, "-")
+			}
+		}
+
+		h, m := convertDecimalToHoursMinutes(total)
+		row = append(row, fmt.Sprintf("%02d:%02d", h, m))
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+
+		if len(missingDays) > 0 {
+			missing = append(missing, fmt.Sprintf("%s (%s)", user.FullName(), strings.Join(missingDays, ", ")))
+		}
+	}
+
+	w.Flush()
+
+	if len(missing) == 0 {
+		fmt.Println("\nEveryone has logged time this week.")
+		return
+	}
+
+	fmt.Println("\nMissing Time:")
+	fmt.Println("-------------")
+	for _, line := range missing {
+		fmt.Printf("- %s\n", line)
+	}
+}
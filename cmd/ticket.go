@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/gitlog"
+	"harvest-cli/pkg/harvest"
+	"strings"
+)
+
+// Ticket represents a ticket ID found by a ticket rule
+type Ticket struct {
+	ID   string
+	Rule *config.TicketRule
+}
+
+// findTicket returns the first ticket ID matched by the ticket rules in the texts, checked in order
+func findTicket(texts ...string) *Ticket {
+	for _, text := range texts {
+		if text == "" {
+			continue
+		}
+
+		for i := range appConfig.TicketRules {
+			rule := &appConfig.TicketRules[i]
+			if id := rule.FindID(text); id != "" {
+				return &Ticket{ID: id, Rule: rule}
+			}
+		}
+	}
+
+	return nil
+}
+
+// currentBranch returns the checked out branch of a repository, or an empty string if there is none
+func currentBranch(repo string) string {
+	if repo == "" {
+		return ""
+	}
+
+	branch, err := gitlog.CurrentBranch(repo)
+	if err != nil || branch == "HEAD" {
+		return ""
+	}
+	return branch
+}
+
+// applyTicket links a time entry request to the ticket in its notes or the branch name
+// of the repository (if any), and prefixes the notes with the ticket ID if they don't contain it
+func applyTicket(request *harvest.TimeEntry, repo string) {
+	if ticket := linkTicket(request, repo); ticket != nil {
+		fmt.Printf("Linked to ticket %s\n", ticket.ID)
+	}
+}
+
+// linkTicket links a time entry request to a ticket like applyTicket, without printing,
+// and returns the ticket or nil if there is none
+func linkTicket(request *harvest.TimeEntry, repo string) *Ticket {
+	if len(appConfig.TicketRules) == 0 {
+		return nil
+	}
+
+	ticket := findTicket(request.Notes, currentBranch(repo))
+	if ticket == nil {
+		return nil
+	}
+
+	if !strings.Contains(request.Notes, ticket.ID) {
+		prefix := ticket.Rule.Prefix
+		if prefix == "" {
+			prefix = "{id} "
+		}
+		request.Notes = strings.ReplaceAll(prefix, "{id}", ticket.ID) + request.Notes
+	}
+
+	if ticket.Rule.Permalink != "" {
+		request.ExternalReference = &harvest.ExternalReference{
+			ID:        ticket.ID,
+			GroupID:   ticket.Rule.GroupID,
+			Permalink: strings.ReplaceAll(ticket.Rule.Permalink, "{id}", ticket.ID),
+		}
+	}
+
+	return ticket
+}
@@ -0,0 +1,885 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"harvest-cli/pkg/journal"
+	"harvest-cli/pkg/terminal"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+// gridRow is a project and task row of the week grid
+type gridRow struct {
+	ProjectID   int64
+	TaskID      int64
+	ProjectName string
+	TaskName    string
+}
+
+// Label returns the display name of the row
+func (r gridRow) Label() string {
+	return r.ProjectName + " | " + r.TaskName
+}
+
+// Modes of the week grid
+const (
+	gridBrowse        = iota // Moving around the grid
+	gridEditDuration         // Editing the duration of an entry, or of a new entry
+	gridEditNotes            // Editing the notes of an entry
+	gridNewNotes             // Entering the notes of a new entry
+	gridConfirmDelete        // Confirming the deletion of an entry
+	gridPickProject          // Picking the project of a new row
+	gridPickTask             // Picking the task of a new row
+)
+
+// gridFixedLines is the number of screen lines of the grid besides the rows
+const gridFixedLines = 9
+
+// weekGrid is the state of the full-screen week grid
+type weekGrid struct {
+	client    *harvest.Client
+	firstDay  time.Weekday // Week start day of the account
+	weekStart time.Time
+	entries   []harvest.TimeEntry
+	rows      []gridRow
+	addedRows []gridRow // Rows kept without entries, such as added rows
+
+	row, col int // Cursor position, the column is the day of the week
+	entry    int // Selected entry of the cell
+	offset   int // First visible row
+
+	mode     int
+	input    []rune
+	newHours float64 // Duration of a new entry while entering its notes
+	status   string
+	warnings bytes.Buffer // Warnings written while the grid is on screen, shown in the status line
+
+	pickItems   []string // Items of the project or task picker
+	pickMatches []int    // Indices of the items matching the input
+	pickPos     int
+	pickProject *config.Project
+}
+
+// TUICmd returns the tui command
+func TUICmd() *cobra.Command {
+	var date string
+
+	cmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Edit the time entries of a week in a full-screen grid",
+		Long: `Show the time entries of a week in a full-screen grid of projects and tasks by day.
+Move with the arrow keys, edit durations and notes in place, add rows, and delete entries;
+each change is saved to Harvest right away and the totals are updated.
+Changes are not saved or queued while Harvest is unreachable; use "h create", "h update"
+or "h delete" to queue changes for "h sync" while offline.
+Use -d flag to open the week of a specific date (YYYY-MM-DD format).
+
+Keys:
+  arrows, h j k l  Move between cells
+  Enter, e         Edit the duration of the entry (or add an entry to an empty cell)
+  n                Edit the notes of the entry
+  Tab              Select the next entry of a cell with several entries
+  a                Add a project and task row
+  d, Delete        Delete the entry
+  [ ]              Previous / next week
+  t                This week
+  r                Reload the week from Harvest
+  q, Esc           Quit`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			targetDate := time.Now()
+			if date != "" {
+				var err error
+				targetDate, err = time.Parse("2006-01-02", date)
+				if err != nil {
+					log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+				}
+			}
+
+			handleTUI(client, targetDate)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().StringVarP(&date, "date", "d", "", "Date in YYYY-MM-DD format (default: today)")
+
+	return cmd
+}
+
+// handleTUI runs the week grid until the user quits
+func handleTUI(client *harvest.Client, targetDate time.Time) {
+	// Weeks start on the week start day of the account, like "h submit"
+	firstDay := time.Monday
+	company, companyErr := client.GetCompany()
+	if companyErr == nil {
+		firstDay = company.WeekStart()
+	}
+
+	term, err := terminal.Open()
+	if err != nil {
+		log.Fatalf("Failed to start the terminal UI: %v", err)
+	}
+	defer term.Close()
+
+	grid := &weekGrid{client: client, firstDay: firstDay, weekStart: startOfWeek(targetDate, firstDay)}
+	if companyErr != nil {
+		fmt.Fprintf(&grid.warnings, "Could not get the week start day, weeks start on Monday: %v\n", companyErr)
+	}
+
+	// Warnings printed on the alternate screen would corrupt the grid
+	warningOutput = &grid.warnings
+	client.SetWarningOutput(&grid.warnings)
+	defer func() {
+		warningOutput = os.Stderr
+		client.SetWarningOutput(os.Stderr)
+	}()
+	grid.reload(term)
+
+	for {
+		term.Draw(grid.render(term.Size()))
+
+		key, err := term.ReadKey()
+		if err != nil {
+			return
+		}
+		if grid.handleKey(term, key) {
+			return
+		}
+	}
+}
+
+// reload fetches the entries of the week from Harvest
+func (g *weekGrid) reload(term *terminal.Terminal) {
+	g.status = "Loading..."
+	term.Draw(g.render(term.Size()))
+
+	from := g.weekStart.Format("2006-01-02")
+	to := g.weekStart.AddDate(0, 0, 6).Format("2006-01-02")
+
+	entries, err := g.client.GetAllTimeEntries(timeEntryParams(from, to))
+	g.entries = entries
+	g.status = ""
+	if err != nil {
+		g.status = fmt.Sprintf("Failed to get time entries: %v", err)
+	}
+	g.status = g.withWarnings(g.status)
+
+	g.buildRows()
+}
+
+// buildRows collects the project and task rows of the entries and the added rows, keeping the cursor on its row
+func (g *weekGrid) buildRows() {
+	var current *gridRow
+	if g.row < len(g.rows) {
+		row := g.rows[g.row]
+		current = &row
+	}
+
+	seen := make(map[[2]int64]bool)
+	g.rows = nil
+	add := func(row gridRow) {
+		key := [2]int64{row.ProjectID, row.TaskID}
+		if !seen[key] {
+			seen[key] = true
+			g.rows = append(g.rows, row)
+		}
+	}
+
+	for _, entry := range g.entries {
+		add(gridRow{
+			ProjectID:   entry.Project.ID,
+			TaskID:      entry.Task.ID,
+			ProjectName: entry.Project.Name,
+			TaskName:    entry.Task.Name,
+		})
+	}
+	for _, row := range g.addedRows {
+		add(row)
+	}
+
+	sort.SliceStable(g.rows, func(i, j int) bool {
+		return strings.ToLower(g.rows[i].Label()) < strings.ToLower(g.rows[j].Label())
+	})
+
+	if current != nil {
+		g.moveToRow(current.ProjectID, current.TaskID)
+	}
+	if g.row >= len(g.rows) {
+		g.row = len(g.rows) - 1
+	}
+	if g.row < 0 {
+		g.row = 0
+	}
+}
+
+// moveToRow moves the cursor to the row of a project and task
+func (g *weekGrid) moveToRow(projectID, taskID int64) {
+	for i, row := range g.rows {
+		if row.ProjectID == projectID && row.TaskID == taskID {
+			g.row = i
+			return
+		}
+	}
+}
+
+// day returns the date of a column
+func (g *weekGrid) day(col int) string {
+	return g.weekStart.AddDate(0, 0, col).Format("2006-01-02")
+}
+
+// cellEntries returns the indices of the entries of a cell
+func (g *weekGrid) cellEntries(row, col int) []int {
+	if row >= len(g.rows) {
+		return nil
+	}
+
+	var indices []int
+	date := g.day(col)
+	for i, entry := range g.entries {
+		if entry.SpentDate == date && entry.Project.ID == g.rows[row].ProjectID && entry.Task.ID == g.rows[row].TaskID {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// selectedEntry returns the selected entry of the cell under the cursor, or nil if it is empty
+func (g *weekGrid) selectedEntry() *harvest.TimeEntry {
+	indices := g.cellEntries(g.row, g.col)
+	if len(indices) == 0 {
+		return nil
+	}
+	if g.entry >= len(indices) {
+		g.entry = 0
+	}
+	return &g.entries[indices[g.entry]]
+}
+
+// handleKey handles a key press, returning true if the user quits
+func (g *weekGrid) handleKey(term *terminal.Terminal, key terminal.Key) bool {
+	switch g.mode {
+	case gridEditDuration, gridEditNotes, gridNewNotes:
+		g.handleInputKey(key)
+	case gridConfirmDelete:
+		g.mode = gridBrowse
+		if key.Code == terminal.KeyRune && (key.Rune == 'y' || key.Rune == 'Y') {
+			g.deleteEntry()
+		} else {
+			g.status = "Deletion cancelled"
+		}
+	case gridPickProject, gridPickTask:
+		g.handlePickerKey(key)
+	default:
+		return g.handleBrowseKey(term, key)
+	}
+	return false
+}
+
+// handleBrowseKey handles a key press while moving around the grid, returning true if the user quits
+func (g *weekGrid) handleBrowseKey(term *terminal.Terminal, key terminal.Key) bool {
+	g.status = ""
+
+	command := key.Code
+	if key.Code == terminal.KeyRune {
+		switch key.Rune {
+		case 'k':
+			command = terminal.KeyUp
+		case 'j':
+			command = terminal.KeyDown
+		case 'h':
+			command = terminal.KeyLeft
+		case 'l':
+			command = terminal.KeyRight
+		case 'e':
+			command = terminal.KeyEnter
+		case 'd':
+			command = terminal.KeyDelete
+		case 'q':
+			return true
+		}
+	}
+
+	switch command {
+	case terminal.KeyEscape, terminal.KeyCtrlC:
+		return true
+	case terminal.KeyUp:
+		if g.row > 0 {
+			g.row--
+			g.entry = 0
+		}
+	case terminal.KeyDown:
+		if g.row < len(g.rows)-1 {
+			g.row++
+			g.entry = 0
+		}
+	case terminal.KeyLeft:
+		if g.col > 0 {
+			g.col--
+			g.entry = 0
+		}
+	case terminal.KeyRight:
+		if g.col < 6 {
+			g.col++
+			g.entry = 0
+		}
+	case terminal.KeyHome:
+		g.col, g.entry = 0, 0
+	case terminal.KeyEnd:
+		g.col, g.entry = 6, 0
+	case terminal.KeyTab:
+		if indices := g.cellEntries(g.row, g.col); len(indices) > 1 {
+			g.entry = (g.entry + 1) % len(indices)
+		}
+	case terminal.KeyEnter:
+		if len(g.rows) == 0 {
+			g.status = "Press a to add a project and task row first"
+			return false
+		}
+		entry := g.selectedEntry()
+		if entry != nil {
+			if err := checkEntryUnlocked(entry, "updated"); err != nil {
+				g.status = err.Error()
+				return false
+			}
+			hours, minutes := convertDecimalToHoursMinutes(entry.Hours)
+			g.startInput(gridEditDuration, fmt.Sprintf("%02d:%02d", hours, minutes))
+		} else {
+			g.startInput(gridEditDuration, "")
+		}
+	case terminal.KeyDelete:
+		entry := g.selectedEntry()
+		if entry == nil {
+			g.status = "No time entry to delete in this cell"
+			return false
+		}
+		if err := checkEntryUnlocked(entry, "deleted"); err != nil {
+			g.status = err.Error()
+			return false
+		}
+		g.mode = gridConfirmDelete
+	case terminal.KeyRune:
+		switch key.Rune {
+		case 'n':
+			entry := g.selectedEntry()
+			if entry == nil {
+				g.status = "No time entry in this cell, press Enter to add one"
+				return false
+			}
+			if err := checkEntryUnlocked(entry, "updated"); err != nil {
+				g.status = err.Error()
+				return false
+			}
+			g.startInput(gridEditNotes, entry.Notes)
+		case 'a':
+			g.pickItems = nil
+			for _, project := range appConfig.Projects {
+				g.pickItems = append(g.pickItems, project.Name)
+			}
+			g.startInput(gridPickProject, "")
+			g.filterPicker()
+		case '[':
+			g.weekStart = g.weekStart.AddDate(0, 0, -7)
+			g.reload(term)
+		case ']':
+			g.weekStart = g.weekStart.AddDate(0, 0, 7)
+			g.reload(term)
+		case 't':
+			g.weekStart = startOfWeek(time.Now(), g.firstDay)
+			g.reload(term)
+		case 'r':
+			g.reload(term)
+		}
+	}
+
+	return false
+}
+
+// startInput switches to a mode that reads text, starting with the given value
+func (g *weekGrid) startInput(mode int, value string) {
+	g.mode = mode
+	g.input = []rune(value)
+	g.status = ""
+}
+
+// handleInputKey handles a key press while editing a duration or notes
+func (g *weekGrid) handleInputKey(key terminal.Key) {
+	switch key.Code {
+	case terminal.KeyEscape, terminal.KeyCtrlC:
+		g.mode = gridBrowse
+		g.status = "Edit cancelled"
+	case terminal.KeyBackspace:
+		if len(g.input) > 0 {
+			g.input = g.input[:len(g.input)-1]
+		}
+	case terminal.KeyRune:
+		g.input = append(g.input, key.Rune)
+	case terminal.KeyEnter:
+		g.commitInput()
+	}
+}
+
+// commitInput saves the edited duration or notes
+func (g *weekGrid) commitInput() {
+	value := strings.TrimSpace(string(g.input))
+
+	switch g.mode {
+	case gridEditDuration:
+		hours, err := parseDuration(value)
+		if err != nil {
+			g.status = fmt.Sprintf("Invalid duration: %v", err)
+			return
+		}
+
+		entry := g.selectedEntry()
+		if entry == nil {
+			// New entries need notes as well
+			g.newHours = hours
+			g.startInput(gridNewNotes, "")
+			return
+		}
+		g.mode = gridBrowse
+		g.updateEntry(entry, hours, entry.Notes)
+	case gridEditNotes, gridNewNotes:
+		if value == "" {
+			g.status = "Notes cannot be blank"
+			return
+		}
+
+		mode := g.mode
+		g.mode = gridBrowse
+		if mode == gridNewNotes {
+			g.createEntry(g.newHours, value)
+		} else if entry := g.selectedEntry(); entry != nil {
+			g.updateEntry(entry, entry.Hours, value)
+		}
+	}
+}
+
+// handlePickerKey handles a key press while picking the project or task of a new row
+func (g *weekGrid) handlePickerKey(key terminal.Key) {
+	switch key.Code {
+	case terminal.KeyEscape, terminal.KeyCtrlC:
+		g.mode = gridBrowse
+		g.status = "Adding a row cancelled"
+		return
+	case terminal.KeyUp:
+		if g.pickPos > 0 {
+			g.pickPos--
+		}
+		return
+	case terminal.KeyDown:
+		if g.pickPos < len(g.pickMatches)-1 {
+			g.pickPos++
+		}
+		return
+	case terminal.KeyBackspace:
+		if len(g.input) > 0 {
+			g.input = g.input[:len(g.input)-1]
+		}
+	case terminal.KeyRune:
+		g.input = append(g.input, key.Rune)
+	case terminal.KeyEnter:
+		if len(g.pickMatches) == 0 {
+			return
+		}
+		g.pick(g.pickItems[g.pickMatches[g.pickPos]])
+		return
+	default:
+		return
+	}
+
+	g.filterPicker()
+}
+
+// filterPicker keeps the picker items containing the input
+func (g *weekGrid) filterPicker() {
+	filter := strings.ToLower(string(g.input))
+
+	g.pickMatches = nil
+	for i, item := range g.pickItems {
+		if strings.Contains(strings.ToLower(item), filter) {
+			g.pickMatches = append(g.pickMatches, i)
+		}
+	}
+	g.pickPos = 0
+}
+
+// pick handles a picked project or task
+func (g *weekGrid) pick(name string) {
+	if g.mode == gridPickProject {
+		g.pickProject = appConfig.GetProjectByName(name)
+		g.pickItems = nil
+		for _, task := range g.pickProject.Tasks {
+			g.pickItems = append(g.pickItems, task.Name)
+		}
+		g.startInput(gridPickTask, "")
+		g.filterPicker()
+		return
+	}
+
+	task := g.pickProject.GetTaskByName(name)
+	row := gridRow{
+		ProjectID:   int64(g.pickProject.ID),
+		TaskID:      int64(task.ID),
+		ProjectName: g.pickProject.Name,
+		TaskName:    task.Name,
+	}
+
+	g.mode = gridBrowse
+	g.addedRows = append(g.addedRows, row)
+	g.buildRows()
+	g.moveToRow(row.ProjectID, row.TaskID)
+	g.entry = 0
+	g.status = fmt.Sprintf("Added %s, press Enter on a day to log time", row.Label())
+}
+
+// createEntry creates an entry in the cell under the cursor
+func (g *weekGrid) createEntry(hours float64, notes string) {
+	row := g.rows[g.row]
+	request := &harvest.TimeEntry{
+		SpentDate: g.day(g.col),
+		ProjectID: int(row.ProjectID),
+		TaskID:    int(row.TaskID),
+		Hours:     hours,
+		Notes:     notes,
+	}
+
+	// Link the entry to the ticket in the notes
+	ticket := linkTicket(request, "")
+
+	created, err := g.client.CreateTimeEntry(request)
+	if err != nil {
+		g.status = g.withWarnings(changeError("create", err))
+		return
+	}
+	recordOperation(journal.ActionCreate, nil, created)
+
+	g.entries = append(g.entries, g.withNames(*created, row))
+	g.entry = len(g.cellEntries(g.row, g.col)) - 1
+	status := fmt.Sprintf("Created time entry %d", created.ID)
+	if ticket != nil {
+		status += fmt.Sprintf(", linked to ticket %s", ticket.ID)
+	}
+	g.status = g.withWarnings(status)
+}
+
+// updateEntry changes the duration and notes of an entry, moving its end time with the duration
+func (g *weekGrid) updateEntry(entry *harvest.TimeEntry, hours float64, notes string) {
+	request := entryRequest(entry)
+	request.Hours = hours
+	request.Notes = notes
+	moveEndTime(request, entry)
+
+	update := harvest.DiffTimeEntry(entry, request)
+	if update.IsEmpty() {
+		g.status = "No changes"
+		return
+	}
+
+	updated, err := g.client.UpdateTimeEntry(entry.ID, update)
+	if err != nil {
+		g.status = g.withWarnings(changeError("update", err))
+		return
+	}
+
+	before := *entry
+	recordOperation(journal.ActionUpdate, &before, updated)
+
+	*entry = g.withNames(*updated, g.rows[g.row])
+	g.status = g.withWarnings(fmt.Sprintf("Updated time entry %d", entry.ID))
+}
+
+// deleteEntry deletes the selected entry
+func (g *weekGrid) deleteEntry() {
+	entry := g.selectedEntry()
+	if entry == nil {
+		return
+	}
+	deleted := *entry
+
+	if err := g.client.DeleteTimeEntry(deleted.ID); err != nil {
+		g.status = g.withWarnings(changeError("delete", err))
+		return
+	}
+	recordOperation(journal.ActionDelete, &deleted, nil)
+
+	// Keep the row until the week is reloaded
+	g.addedRows = append(g.addedRows, g.rows[g.row])
+	for i := range g.entries {
+		if g.entries[i].ID == deleted.ID {
+			g.entries = append(g.entries[:i], g.entries[i+1:]...)
+			break
+		}
+	}
+	g.entry = 0
+	g.buildRows()
+	g.status = g.withWarnings(fmt.Sprintf("Deleted time entry %d", deleted.ID))
+}
+
+// withWarnings adds the warnings written since the last change to a status message
+func (g *weekGrid) withWarnings(status string) string {
+	warnings := strings.TrimSpace(g.warnings.String())
+	g.warnings.Reset()
+	if warnings == "" {
+		return status
+	}
+	warnings = strings.ReplaceAll(warnings, "\n", "; ")
+	if status == "" {
+		return warnings
+	}
+	return status + " - " + warnings
+}
+
+// withNames fills in the project and task of an entry returned without them
+func (g *weekGrid) withNames(entry harvest.TimeEntry, row gridRow) harvest.TimeEntry {
+	entry.Project.ID = row.ProjectID
+	entry.Task.ID = row.TaskID
+	if entry.Project.Name == "" {
+		entry.Project.Name = row.ProjectName
+	}
+	if entry.Task.Name == "" {
+		entry.Task.Name = row.TaskName
+	}
+	return entry
+}
+
+// changeError describes a failed change, which is not saved. Unlike the other commands, the grid
+// doesn't queue changes while Harvest is unreachable, since it couldn't show them.
+func changeError(action string, err error) string {
+	if harvest.IsNetworkError(err) {
+		return fmt.Sprintf("Harvest is unreachable, the %s was not saved or queued: %v", action, err)
+	}
+	return fmt.Sprintf("Failed to %s time entry: %v", action, err)
+}
+
+// render returns the lines of the screen
+func (g *weekGrid) render(width, height int) []string {
+	weekEnd := g.weekStart.AddDate(0, 0, 6)
+	lines := []string{
+		terminal.Bold + fitWidth(fmt.Sprintf("Week of %s to %s", g.weekStart.Format("Jan 2"), weekEnd.Format("Jan 2, 2006")), width) + terminal.Reset,
+	}
+
+	if g.mode == gridPickProject || g.mode == gridPickTask {
+		return append(lines, g.renderPicker(width, height-1)...)
+	}
+
+	// Rows are labelled with project and task names, fitted into the space left by the days
+	labelWidth := 5
+	for _, row := range g.rows {
+		if n := utf8.RuneCountInString(row.Label()); n > labelWidth {
+			labelWidth = n
+		}
+	}
+	if maxWidth := width - 8*8 - 1; labelWidth > maxWidth {
+		labelWidth = maxWidth
+	}
+	if labelWidth < 5 {
+		labelWidth = 5
+	}
+
+	// Day headers, today in bold
+	today := time.Now().Format("2006-01-02")
+	header := padWidth("", labelWidth)
+	for col := 0; col < 7; col++ {
+		day := fmt.Sprintf("%8s", g.weekStart.AddDate(0, 0, col).Format("Mon 02"))
+		if g.day(col) == today {
+			day = terminal.Bold + day + terminal.Reset
+		}
+		header += day
+	}
+	lines = append(lines, header+fmt.Sprintf("%8s", "Total"))
+	lines = append(lines, strings.Repeat("-", labelWidth+8*8))
+
+	// Keep the cursor row visible
+	visible := height - gridFixedLines
+	if visible < 1 {
+		visible = 1
+	}
+	if g.row < g.offset {
+		g.offset = g.row
+	}
+	if g.row >= g.offset+visible {
+		g.offset = g.row - visible + 1
+	}
+
+	var dayTotals [7]float64
+	var weekTotal float64
+	for _, entry := range g.entries {
+		for col := 0; col < 7; col++ {
+			if entry.SpentDate == g.day(col) {
+				dayTotals[col] += entry.Hours
+				weekTotal += entry.Hours
+			}
+		}
+	}
+
+	if len(g.rows) == 0 {
+		lines = append(lines, fitWidth("No time entries this week. Press a to add a project and task row.", width))
+	}
+	for i := g.offset; i < len(g.rows) && i < g.offset+visible; i++ {
+		line := padWidth(g.rows[i].Label(), labelWidth)
+
+		var rowTotal float64
+		for col := 0; col < 7; col++ {
+			indices := g.cellEntries(i, col)
+
+			var hours float64
+			for _, index := range indices {
+				hours += g.entries[index].Hours
+			}
+			rowTotal += hours
+
+			// Cells with several entries are marked with +
+			cell := fmt.Sprintf("%7s ", "-")
+			if len(indices) > 0 {
+				cell = fmt.Sprintf("%7s ", formatGridHours(hours))
+			}
+			if len(indices) > 1 {
+				cell = cell[:7] + "+"
+			}
+			if i == g.row && col == g.col {
+				cell = terminal.Reverse + cell + terminal.Reset
+			}
+			line += cell
+		}
+
+		lines = append(lines, line+fmt.Sprintf("%8s", formatGridHours(rowTotal)))
+	}
+
+	lines = append(lines, strings.Repeat("-", labelWidth+8*8))
+	totals := padWidth("Total", labelWidth)
+	for _, hours := range dayTotals {
+		totals += fmt.Sprintf("%7s ", formatGridHours(hours))
+	}
+	lines = append(lines, totals+fmt.Sprintf("%8s", formatGridHours(weekTotal)))
+
+	lines = append(lines, "")
+	lines = append(lines, fitWidth(g.describeCell(), width))
+	lines = append(lines, fitWidth(g.prompt(), width))
+	lines = append(lines, terminal.Dim+fitWidth("arrows move  Enter time  n notes  Tab next entry  a add row  d delete  [ ] week  r reload  q quit", width)+terminal.Reset)
+
+	return lines
+}
+
+// renderPicker returns the lines of the project or task picker
+func (g *weekGrid) renderPicker(width, height int) []string {
+	label := "Project"
+	if g.mode == gridPickTask {
+		label = "Task of " + g.pickProject.Name
+	}
+
+	lines := []string{
+		"",
+		fitWidth(fmt.Sprintf("%s (type to filter, Enter to select, Esc to cancel): %s_", label, string(g.input)), width),
+	}
+	if len(g.pickMatches) == 0 {
+		return append(lines, "No matches")
+	}
+
+	// Show a window of items around the selected one
+	visible := height - len(lines)
+	if visible < 1 {
+		visible = 1
+	}
+	start := 0
+	if g.pickPos >= visible {
+		start = g.pickPos - visible + 1
+	}
+
+	for i := start; i < len(g.pickMatches) && i < start+visible; i++ {
+		item := fitWidth("  "+g.pickItems[g.pickMatches[i]], width)
+		if i == g.pickPos {
+			item = terminal.Reverse + fitWidth("> "+g.pickItems[g.pickMatches[i]], width) + terminal.Reset
+		}
+		lines = append(lines, item)
+	}
+
+	return lines
+}
+
+// describeCell describes the cell under the cursor and its selected entry
+func (g *weekGrid) describeCell() string {
+	if len(g.rows) == 0 {
+		return ""
+	}
+
+	day := g.weekStart.AddDate(0, 0, g.col).Format("Mon Jan 2")
+	description := fmt.Sprintf("%s, %s", day, g.rows[g.row].Label())
+
+	entry := g.selectedEntry()
+	if entry == nil {
+		return description + ": no time entries"
+	}
+
+	indices := g.cellEntries(g.row, g.col)
+	if len(indices) > 1 {
+		description += fmt.Sprintf(" (entry %d of %d)", g.entry+1, len(indices))
+	}
+	description += fmt.Sprintf(": #%d %s", entry.ID, formatGridHours(entry.Hours))
+	if start, end := formatClockRange(*entry); start != "-" {
+		description += fmt.Sprintf(" %s-%s", start, end)
+	}
+	if entry.IsLocked {
+		description += " (locked)"
+	}
+	return description + " " + entry.Notes
+}
+
+// prompt returns the input line of the current mode, or the status message
+func (g *weekGrid) prompt() string {
+	switch g.mode {
+	case gridEditDuration:
+		return fmt.Sprintf("Duration (HH:MM, Enter to save, Esc to cancel): %s_", string(g.input))
+	case gridEditNotes:
+		return fmt.Sprintf("Notes (Enter to save, Esc to cancel): %s_", string(g.input))
+	case gridNewNotes:
+		return fmt.Sprintf("Notes of the new %s entry (Enter to create, Esc to cancel): %s_", formatGridHours(g.newHours), string(g.input))
+	case gridConfirmDelete:
+		entry := g.selectedEntry()
+		return fmt.Sprintf("Delete time entry %d (%s %s)? (y/n)", entry.ID, formatGridHours(entry.Hours), entry.Notes)
+	}
+	return g.status
+}
+
+// formatGridHours formats decimal hours as HH:MM
+func formatGridHours(hours float64) string {
+	h, m := convertDecimalToHoursMinutes(hours)
+	return fmt.Sprintf("%02d:%02d", h, m)
+}
+
+// fitWidth truncates text to a number of characters
+func fitWidth(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// padWidth truncates or pads text to exactly a number of characters, plus a separating space
+func padWidth(text string, width int) string {
+	text = fitWidth(text, width)
+	return text + strings.Repeat(" ", width-utf8.RuneCountInString(text)+1)
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/harvest"
+	"harvest-cli/pkg/journal"
+	"log"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// UndoCmd returns the undo command
+func UndoCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Undo the last create, update or delete",
+		Long: `Reverse the most recent time entry operation recorded in the journal.
+Created entries are deleted, updated entries are reverted to their previous values
+and deleted entries are recreated (with a new ID).
+Run it again to undo earlier operations. Use "h history" to see the journal.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Load configuration
+			var err error
+			appConfig, err = config.LoadConfig()
+			if err != nil {
+				log.Fatalf("Failed to load configuration: %v", err)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// Create Harvest API client
+			client := newHarvestClient()
+
+			handleUndo(client, yes)
+		},
+	}
+
+	// Define flags
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Undo without asking for confirmation")
+
+	return cmd
+}
+
+// handleUndo reverses the most recent operation in the journal
+func handleUndo(client *harvest.Client, yes bool) {
+	j, err := openJournal()
+	if err != nil {
+		log.Fatalf("Failed to open journal: %v", err)
+	}
+
+	op, err := j.LastUndoable(appConfig.HarvestAPI.AccountID)
+	if err != nil {
+		log.Fatalf("Failed to read journal: %v", err)
+	}
+	if op == nil {
+		fmt.Println("Nothing to undo")
+		return
+	}
+
+	fmt.Printf("Last operation (#%d at %s):\n", op.ID, op.Time.Local().Format("2006-01-02 15:04"))
+	fmt.Printf("%s\n", describeOperation(op))
+
+	if !yes && !confirmSelect("Undo this operation", "Cancel") {
+		fmt.Println("Undo cancelled")
+		return
+	}
+
+	switch op.Action {
+	case journal.ActionCreate:
+		// Delete the created entry
+		current := getUndoTarget(client, op.EntryID, op.After, yes)
+		if err := checkEntryUnlocked(current, "deleted"); err != nil {
+			log.Fatal(err)
+		}
+
+		if err := client.DeleteTimeEntry(current.ID); err != nil {
+			log.Fatalf("Failed to delete time entry: %v", err)
+		}
+		appendOperation(&journal.Operation{Action: journal.ActionDelete, Before: current, Undoes: op.ID})
+
+		fmt.Printf("Time entry %d deleted\n", current.ID)
+	case journal.ActionUpdate:
+		// Revert the entry to its previous values
+		current := getUndoTarget(client, op.EntryID, op.After, yes)
+		if err := checkEntryUnlocked(current, "updated"); err != nil {
+			log.Fatal(err)
+		}
+
+		update := harvest.DiffTimeEntry(current, entryRequest(op.Before))
+		if update.IsEmpty() {
+			// Nothing to send, but the operation is undone
+			appendOperation(&journal.Operation{Action: journal.ActionUpdate, Before: current, After: current, Undoes: op.ID})
+			fmt.Printf("Time entry %d already has its previous values\n", current.ID)
+			return
+		}
+
+		reverted, err := client.UpdateTimeEntry(current.ID, update)
+		if err != nil {
+			log.Fatalf("Failed to update time entry: %v", err)
+		}
+		appendOperation(&journal.Operation{Action: journal.ActionUpdate, Before: current, After: reverted, Undoes: op.ID})
+
+		fmt.Printf("Time entry %d reverted\n", reverted.ID)
+	case journal.ActionDelete:
+		// Recreate the deleted entry
+		created, err := client.CreateTimeEntry(entryRequest(op.Before))
+		if err != nil {
+			log.Fatalf("Failed to create time entry: %v", err)
+		}
+		appendOperation(&journal.Operation{Action: journal.ActionCreate, After: created, Undoes: op.ID})
+
+		fmt.Printf("Time entry %d recreated as time entry %d\n", op.EntryID, created.ID)
+	default:
+		log.Fatalf("Unknown operation in journal: %s", op.Action)
+	}
+}
+
+// getUndoTarget fetches the current state of an entry and asks for confirmation
+// if it was changed after the snapshot in the journal was taken
+func getUndoTarget(client *harvest.Client, id int64, snapshot *harvest.TimeEntry, yes bool) *harvest.TimeEntry {
+	current, err := client.GetTimeEntry(id)
+	if err != nil {
+		log.Fatalf("Failed to get time entry %d: %v", id, err)
+	}
+
+	if snapshot != nil && !snapshot.UpdatedAt.IsZero() && !current.UpdatedAt.Equal(snapshot.UpdatedAt) {
+		fmt.Printf("\nWarning: time entry %d was changed after this operation (last updated %s)\n",
+			id, current.UpdatedAt.Local().Format("2006-01-02 15:04"))
+		if !yes && !confirmSelect("Undo anyway", "Cancel") {
+			log.Fatalf("Undo cancelled")
+		}
+	}
+
+	return current
+}
+
+// describeOperation returns a one-line description of a journal operation
+func describeOperation(op *journal.Operation) string {
+	switch op.Action {
+	case journal.ActionCreate:
+		return fmt.Sprintf("Created time entry %d: %s", op.EntryID, describeEntry(op.After))
+	case journal.ActionUpdate:
+		return fmt.Sprintf("Updated time entry %d: %s -> %s", op.EntryID, describeEntry(op.Before), describeEntry(op.After))
+	case journal.ActionDelete:
+		return fmt.Sprintf("Deleted time entry %d: %s", op.EntryID, describeEntry(op.Before))
+	default:
+		return fmt.Sprintf("%s time entry %d", op.Action, op.EntryID)
+	}
+}
+
+// describeEntry returns a short description of a time entry snapshot
+func describeEntry(entry *harvest.TimeEntry) string {
+	if entry == nil {
+		return "-"
+	}
+
+	hours, minutes := convertDecimalToHoursMinutes(entry.Hours)
+	description := fmt.Sprintf("%s %s | %s (%02d:%02d)", entry.SpentDate, entry.Project.Name, entry.Task.Name, hours, minutes)
+	if entry.Notes != "" {
+		description += " - " + entry.Notes
+	}
+	return description
+}
+
+// confirmSelect asks the user to choose between confirming and cancelling
+func confirmSelect(confirmLabel, cancelLabel string) bool {
+	prompt := promptui.Select{
+		Label: "What would you like to do?",
+		Items: []string{confirmLabel, cancelLabel},
+	}
+
+	index, _, err := prompt.Run()
+	if err != nil {
+		log.Fatalf("Prompt failed: %v", err)
+	}
+
+	return index == 0
+}
@@ -4,25 +4,47 @@ import (
 	"fmt"
 	"harvest-cli/pkg/config"
 	"harvest-cli/pkg/harvest"
+	"harvest-cli/pkg/journal"
+	"harvest-cli/pkg/queue"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
+// updateFields holds the field flags of a non-interactive update
+type updateFields struct {
+	Date        string
+	Project     string
+	Task        string
+	Time        string
+	Notes       string
+	AppendNotes string
+	NotesSet    bool // --notes was given, possibly empty to clear the notes
+}
+
 // UpdateCmd returns the update command
 func UpdateCmd() *cobra.Command {
-	var interactive bool
+	var interactive, jsonOutput bool
 	var date string
+	var fields updateFields
 
 	cmd := &cobra.Command{
-		Use:   "update",
+		Use:   "update [id]",
 		Short: "Update a time entry",
 		Long: `Update a time entry.
 By default, shows all time entries for today and lets you select one to update.
-Use -i flag for interactive mode to select a time entry to update.
-Use -d flag to specify a date (YYYY-MM-DD format) for time entry selection.`,
+Use -d flag to specify a date (YYYY-MM-DD format) for time entry selection.
+
+Give a time entry ID to update it without prompts, changing only the fields given
+by flags; with an ID, -d moves the entry to another date.
+Example: h update 123456789 -t 2:30 --append-notes "code review"
+Use -n "" to clear the notes of the entry.
+Use --json flag to print the updated entry as JSON.`,
+		Args: cobra.MaximumNArgs(1),
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			// Load configuration
 			var err error
@@ -33,7 +55,20 @@ Use -d flag to specify a date (YYYY-MM-DD format) for time entry selection.`,
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			// Create Harvest API client
-			client := harvest.NewClient(&appConfig.HarvestAPI)
+			client := newHarvestClient()
+
+			// Update by ID without prompts
+			if len(args) == 1 {
+				fields.Date = date
+				fields.NotesSet = cmd.Flags().Changed("notes")
+				handleUpdateByID(client, args[0], fields, jsonOutput)
+				return
+			}
+			for _, name := range []string{"project", "task", "time", "notes", "append-notes", "json"} {
+				if cmd.Flags().Changed(name) {
+					log.Fatalf("The --%s flag requires a time entry ID", name)
+				}
+			}
 
 			// Parse the date if provided, otherwise use today
 			var targetDate string
@@ -88,7 +123,14 @@ Use -d flag to specify a date (YYYY-MM-DD format) for time entry selection.`,
 
 	// Define flags
 	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Use interactive mode to select a time entry to update (deprecated, now the default behavior)")
-	cmd.Flags().StringVarP(&date, "date", "d", "", "Date in YYYY-MM-DD format (default: today)")
+	cmd.Flags().StringVarP(&date, "date", "d", "", "Date in YYYY-MM-DD format (default: today), or the new date of the entry with an ID")
+	cmd.Flags().StringVarP(&fields.Project, "project", "p", "", "New project (with an ID)")
+	cmd.Flags().StringVarP(&fields.Task, "task", "a", "", "New task (with an ID)")
+	cmd.Flags().StringVarP(&fields.Time, "time", "t", "", "New duration in HH:MM format (with an ID)")
+	cmd.Flags().StringVarP(&fields.Notes, "notes", "n", "", "New notes (with an ID), empty to clear the notes")
+	cmd.Flags().StringVar(&fields.AppendNotes, "append-notes", "", "Text to add to the notes (with an ID)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the updated entry as JSON (with an ID)")
+	cmd.Flags().MarkDeprecated("interactive", "selecting a time entry is the default without an ID")
 
 	return cmd
 }
@@ -123,6 +165,9 @@ func handleTimeEntrySelection(client *harvest.Client, date string) {
 			hours,
 			minutes,
 			entry.Notes)
+		if entry.IsLocked {
+			timeEntryOptions[i] += " [locked]"
+		}
 	}
 
 	prompt := promptui.Select{
@@ -138,6 +183,12 @@ func handleTimeEntrySelection(client *harvest.Client, date string) {
 
 	selectedEntry := timeEntries[index]
 
+	// Locked entries cannot be changed
+	if err := checkEntryUnlocked(&selectedEntry, "updated"); err != nil {
+		fmt.Println(err)
+		return
+	}
+
 	// Display selected time entry details
 	hours, minutes := convertDecimalToHoursMinutes(selectedEntry.Hours)
 	fmt.Println("\nSelected Time Entry Details:")
@@ -173,8 +224,8 @@ func handleTimeEntrySelection(client *harvest.Client, date string) {
 
 // updateTimeEntry updates a time entry with user input
 func updateTimeEntry(client *harvest.Client, entry *harvest.TimeEntry) {
-	// Create update request
-	updateRequest := &harvest.TimeEntry{}
+	// Start from the current values, only the changed fields are sent
+	updateRequest := entryRequest(entry)
 
 	// Prompt for date
 	datePrompt := promptui.Prompt{
@@ -287,6 +338,7 @@ func updateTimeEntry(client *harvest.Client, entry *harvest.TimeEntry) {
 
 	timeValue, _ := parseDuration(timeResult)
 	updateRequest.Hours = timeValue
+	moveEndTime(updateRequest, entry)
 
 	// Prompt for notes
 	notesPrompt := promptui.Prompt{
@@ -332,14 +384,195 @@ func updateTimeEntry(client *harvest.Client, entry *harvest.TimeEntry) {
 		return
 	}
 
+	update := harvest.DiffTimeEntry(entry, updateRequest)
+	if update.IsEmpty() {
+		fmt.Println("No changes to save")
+		return
+	}
+
 	// Update the time entry
-	updatedEntry, err := client.UpdateTimeEntry(entry.ID, updateRequest)
+	updatedEntry, err := client.UpdateTimeEntry(entry.ID, update)
+	if harvest.IsNetworkError(err) {
+		queueOperation(queue.ActionUpdate, entry.ID, updateRequest, entry, err)
+		return
+	}
+	if err != nil {
+		log.Fatalf("Failed to update time entry: %v", err)
+	}
+	recordOperation(journal.ActionUpdate, entry, updatedEntry)
+
+	displayUpdatedEntry(updatedEntry)
+}
+
+// handleUpdateByID changes the given fields of a time entry without prompts
+func handleUpdateByID(client *harvest.Client, idArg string, fields updateFields, jsonOutput bool) {
+	id, err := strconv.ParseInt(idArg, 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid time entry ID: %s", idArg)
+	}
+	if fields.Date == "" && fields.Project == "" && fields.Task == "" && fields.Time == "" && !fields.NotesSet && fields.AppendNotes == "" {
+		log.Fatalf("Nothing to update, use -d, -p, -a, -t, -n or --append-notes")
+	}
+
+	entry, err := client.GetTimeEntry(id)
+	if harvest.IsNetworkError(err) {
+		// Scripts need a definite result, so changes are only queued for sync without --json
+		if jsonOutput {
+			log.Fatalf("Harvest is unreachable, the update can't be queued with --json: %v", err)
+		}
+		handleOfflineUpdate(id, fields, err)
+		return
+	}
+	if harvest.IsNotFound(err) {
+		log.Fatalf("Time entry %d not found", id)
+	}
+	if err != nil {
+		log.Fatalf("Failed to get time entry: %v", err)
+	}
+
+	// Locked entries cannot be changed
+	if err := checkEntryUnlocked(entry, "updated"); err != nil {
+		log.Fatal(err)
+	}
+
+	updateRequest := entryRequest(entry)
+
+	if fields.Date != "" {
+		if _, err := time.Parse("2006-01-02", fields.Date); err != nil {
+			log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+		}
+		updateRequest.SpentDate = fields.Date
+	}
+
+	// The task is kept when moving to another project that has it
+	if fields.Project != "" || fields.Task != "" {
+		project := appConfig.GetProjectByID(int(entry.Project.ID))
+		if fields.Project != "" {
+			project = appConfig.GetProjectByName(fields.Project)
+			if project == nil {
+				log.Fatalf("Project '%s' not found in configuration", fields.Project)
+			}
+		}
+		if project == nil {
+			log.Fatalf("Project '%s' of time entry %d not found in configuration, use --project", entry.Project.Name, id)
+		}
+
+		var task *config.Task
+		if fields.Task != "" {
+			task = project.GetTaskByName(fields.Task)
+			if task == nil {
+				log.Fatalf("Task '%s' not found in project '%s'", fields.Task, project.Name)
+			}
+		} else {
+			task = project.GetTaskByID(int(entry.Task.ID))
+			if task == nil {
+				log.Fatalf("Task '%s' not found in project '%s', use --task", entry.Task.Name, project.Name)
+			}
+		}
+
+		updateRequest.ProjectID = project.ID
+		updateRequest.TaskID = task.ID
+	}
+
+	if fields.Time != "" {
+		hours, err := parseDuration(fields.Time)
+		if err != nil {
+			log.Fatalf("Invalid duration format: %v", err)
+		}
+		updateRequest.Hours = hours
+		moveEndTime(updateRequest, entry)
+	}
+
+	if fields.NotesSet {
+		updateRequest.Notes = fields.Notes
+	}
+	if fields.AppendNotes != "" {
+		if strings.TrimSpace(updateRequest.Notes) == "" {
+			updateRequest.Notes = fields.AppendNotes
+		} else {
+			updateRequest.Notes += "; " + fields.AppendNotes
+		}
+	}
+
+	update := harvest.DiffTimeEntry(entry, updateRequest)
+	if update.IsEmpty() {
+		if jsonOutput {
+			printJSON(entry)
+			return
+		}
+		fmt.Printf("Time entry %d already has these values\n", id)
+		return
+	}
+
+	// Scripts need a definite result, so changes are only queued for sync without --json
+	updatedEntry, err := client.UpdateTimeEntry(id, update)
+	if harvest.IsNetworkError(err) && !jsonOutput {
+		queueOperation(queue.ActionUpdate, id, updateRequest, entry, err)
+		return
+	}
 	if err != nil {
 		log.Fatalf("Failed to update time entry: %v", err)
 	}
+	recordOperation(journal.ActionUpdate, entry, updatedEntry)
+
+	if jsonOutput {
+		printJSON(updatedEntry)
+		return
+	}
+	displayUpdatedEntry(updatedEntry)
+}
+
+// handleOfflineUpdate queues the fields given by flags for an entry that can't be fetched
+// because Harvest is unreachable. Changes that depend on the current entry are refused.
+func handleOfflineUpdate(id int64, fields updateFields, cause error) {
+	if fields.AppendNotes != "" {
+		log.Fatalf("Harvest is unreachable, --append-notes needs the current notes, use --notes instead: %v", cause)
+	}
+	if (fields.Project == "") != (fields.Task == "") {
+		log.Fatalf("Harvest is unreachable, give both --project and --task to move the entry: %v", cause)
+	}
+
+	update := &harvest.TimeEntryUpdate{}
+
+	if fields.Date != "" {
+		if _, err := time.Parse("2006-01-02", fields.Date); err != nil {
+			log.Fatalf("Invalid date format. Please use YYYY-MM-DD format: %v", err)
+		}
+		update.SpentDate = &fields.Date
+	}
+
+	if fields.Project != "" {
+		project := appConfig.GetProjectByName(fields.Project)
+		if project == nil {
+			log.Fatalf("Project '%s' not found in configuration", fields.Project)
+		}
+		task := project.GetTaskByName(fields.Task)
+		if task == nil {
+			log.Fatalf("Task '%s' not found in project '%s'", fields.Task, project.Name)
+		}
+		update.ProjectID = &project.ID
+		update.TaskID = &task.ID
+	}
+
+	// Without the entry, the end time of timestamp entries isn't moved with the duration
+	if fields.Time != "" {
+		hours, err := parseDuration(fields.Time)
+		if err != nil {
+			log.Fatalf("Invalid duration format: %v", err)
+		}
+		update.Hours = &hours
+	}
+
+	if fields.NotesSet {
+		update.Notes = &fields.Notes
+	}
+
+	queueFieldUpdate(id, update, cause)
+}
 
-	// Display updated time entry details
-	hours, minutes = convertDecimalToHoursMinutes(updatedEntry.Hours)
+// displayUpdatedEntry prints the details of an updated time entry
+func displayUpdatedEntry(updatedEntry *harvest.TimeEntry) {
+	hours, minutes := convertDecimalToHoursMinutes(updatedEntry.Hours)
 	fmt.Println("\nTime Entry Updated Successfully:")
 	fmt.Printf("ID: %d\n", updatedEntry.ID)
 	fmt.Printf("Date: %s\n", updatedEntry.SpentDate)
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"harvest-cli/pkg/harvest"
+	"sync"
+	"time"
+)
+
+// Harvest allows 100 requests per 15 seconds, rate limited requests are retried after the window
+const (
+	rateLimitRetryDelay = 15 * time.Second
+	rateLimitRetries    = 3
+)
+
+// runConcurrently calls work for each index from 0 to n-1 on at most the given number of
+// goroutines and waits until all calls have returned
+func runConcurrently(n, workers int, work func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				work(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+}
+
+// rateLimiter spaces out requests shared by several goroutines
+type rateLimiter struct {
+	ticker *time.Ticker
+}
+
+// newRateLimiter returns a limiter that lets through at most the given number of requests per second
+func newRateLimiter(perSecond float64) *rateLimiter {
+	if perSecond <= 0 {
+		perSecond = 1
+	}
+	return &rateLimiter{ticker: time.NewTicker(time.Duration(float64(time.Second) / perSecond))}
+}
+
+// Wait blocks until the next request may be sent
+func (l *rateLimiter) Wait() {
+	<-l.ticker.C
+}
+
+// Stop releases the limiter
+func (l *rateLimiter) Stop() {
+	l.ticker.Stop()
+}
+
+// withRetry sends a request once the limiter allows it, waiting and retrying while Harvest
+// rate limits the requests
+func withRetry(limiter *rateLimiter, label string, request func() error) error {
+	for attempt := 1; ; attempt++ {
+		limiter.Wait()
+		err := request()
+		if !harvest.IsRateLimited(err) || attempt > rateLimitRetries {
+			return err
+		}
+
+		delay := rateLimitRetryDelay * time.Duration(attempt)
+		fmt.Printf("Rate limited by Harvest, retrying %s in %s\n", label, delay)
+		time.Sleep(delay)
+	}
+}
@@ -110,7 +110,7 @@ if [[ ! -f "$CONFIG_HOME" ]]; then
   "default_project": "Project A",
   "default_task": "Software Development",
   "year_start_date": "01-01",
-  "monthly_capacity_hours": 160,
+  "daily_hours": 8,
   "billable_task_ids": [456],
   "harvest_api": {
     "account_id": "YOUR_HARVEST_ACCOUNT_ID",
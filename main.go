@@ -27,6 +27,23 @@ Complete documentation is available at https://github.com/amanangira/harvest-cli
 	rootCmd.AddCommand(cmd.UpdateCmd())
 	rootCmd.AddCommand(cmd.ListCmd())
 	rootCmd.AddCommand(cmd.ConfigCmd())
+	rootCmd.AddCommand(cmd.BalanceCmd())
+	rootCmd.AddCommand(cmd.BudgetCmd())
+	rootCmd.AddCommand(cmd.ReportCmd())
+	rootCmd.AddCommand(cmd.TeamCmd())
+	rootCmd.AddCommand(cmd.SubmitCmd())
+	rootCmd.AddCommand(cmd.ExpenseCmd())
+	rootCmd.AddCommand(cmd.ProjectsCmd())
+	rootCmd.AddCommand(cmd.TasksCmd())
+	rootCmd.AddCommand(cmd.SearchCmd())
+	rootCmd.AddCommand(cmd.UndoCmd())
+	rootCmd.AddCommand(cmd.HistoryCmd())
+	rootCmd.AddCommand(cmd.AuditCmd())
+	rootCmd.AddCommand(cmd.SyncCmd())
+	rootCmd.AddCommand(cmd.HooksCmd())
+	rootCmd.AddCommand(cmd.ImportCalendarCmd())
+	rootCmd.AddCommand(cmd.TUICmd())
+	rootCmd.AddCommand(cmd.BulkUpdateCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
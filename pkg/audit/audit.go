@@ -0,0 +1,123 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileName is the name of the audit log file in the data directory
+const FileName = "audit.jsonl"
+
+// Entry represents a single API mutation recorded in the audit log
+type Entry struct {
+	Time     time.Time       `json:"time"`
+	Profile  string          `json:"profile"` // Harvest account ID the request was sent to
+	Action   string          `json:"action"`  // "create", "update" or "delete"
+	Method   string          `json:"method"`
+	URL      string          `json:"url"`
+	EntryID  int64           `json:"entry_id,omitempty"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Status   int             `json:"status"` // HTTP status code, 0 if no response was received
+	Error    string          `json:"error,omitempty"`
+}
+
+// Log is an append-only audit log stored as JSON lines
+type Log struct {
+	path string
+	mu   sync.Mutex // Serializes writes from concurrent requests
+}
+
+// Open returns the audit log stored in the data directory
+func Open(dataDir string) *Log {
+	return &Log{path: filepath.Join(dataDir, FileName)}
+}
+
+// Path returns the path of the audit log file
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Record appends an entry to the audit log
+func (l *Log) Record(entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	return nil
+}
+
+// Entries returns the entries recorded between two dates (both inclusive, in local time), oldest first
+func (l *Log) Entries(from, to time.Time) ([]Entry, error) {
+	file, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.Local)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse audit log line %d: %w", line, err)
+		}
+
+		if entry.Time.Before(start) || !entry.Time.Before(end) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Body converts a request or response body into JSON for an entry, quoting bodies that aren't JSON
+func Body(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return json.RawMessage(body)
+	}
+
+	quoted, _ := json.Marshal(string(body))
+	return json.RawMessage(quoted)
+}
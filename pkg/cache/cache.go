@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"harvest-cli/pkg/harvest"
+)
+
+// DirName is the name of the cache directory in the data directory
+const DirName = "cache"
+
+// Range holds the time entries of a date range as fetched from Harvest
+type Range struct {
+	AccountID   string              `json:"account_id"`
+	Params      map[string]string   `json:"params"`       // Query parameters of the range, including from and to
+	FetchedAt   time.Time           `json:"fetched_at"`   // Time of the last full fetch
+	RefreshedAt time.Time           `json:"refreshed_at"` // Time of the last incremental refresh
+	Entries     []harvest.TimeEntry `json:"entries"`
+}
+
+// Cache stores time entry ranges in a directory, one file per profile and query
+type Cache struct {
+	dir string
+}
+
+// Open returns the cache in the data directory
+func Open(dataDir string) *Cache {
+	return &Cache{dir: filepath.Join(dataDir, DirName)}
+}
+
+// Load reads the cached range for a profile and query, or returns nil if it isn't cached
+func (c *Cache) Load(accountID string, params map[string]string) (*Range, error) {
+	data, err := os.ReadFile(c.path(accountID, params))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	var r Range
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse cache: %w", err)
+	}
+
+	return &r, nil
+}
+
+// Save writes a range to the cache
+func (c *Cache) Save(r *Range) error {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+
+	if err := os.WriteFile(c.path(r.AccountID, r.Params), data, 0600); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+
+	return nil
+}
+
+// Invalidate removes every cached range of a profile
+func (c *Cache) Invalidate(accountID string) error {
+	files, err := filepath.Glob(filepath.Join(c.dir, accountID+"-*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove cache file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// path returns the file of a profile and query, named after a hash of the query parameters
+func (c *Cache) path(accountID string, params map[string]string) string {
+	var keys []string
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var query []string
+	for _, key := range keys {
+		query = append(query, key+"="+params[key])
+	}
+
+	sum := sha1.Sum([]byte(strings.Join(query, "&")))
+	return filepath.Join(c.dir, fmt.Sprintf("%s-%s.json", accountID, hex.EncodeToString(sum[:8])))
+}
+
+// Merge applies entries updated since the last refresh. Entries moved to a date
+// outside the range are removed, the others are added or replaced.
+func (r *Range) Merge(updated []harvest.TimeEntry) {
+	byID := make(map[int64]harvest.TimeEntry, len(r.Entries))
+	for _, entry := range r.Entries {
+		byID[entry.ID] = entry
+	}
+
+	for _, entry := range updated {
+		if r.contains(entry.SpentDate) {
+			byID[entry.ID] = entry
+		} else {
+			delete(byID, entry.ID)
+		}
+	}
+
+	entries := make([]harvest.TimeEntry, 0, len(byID))
+	for _, entry := range byID {
+		entries = append(entries, entry)
+	}
+
+	// Keep the order of the Harvest API, most recent first
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].SpentDate != entries[j].SpentDate {
+			return entries[i].SpentDate > entries[j].SpentDate
+		}
+		return entries[i].ID > entries[j].ID
+	})
+
+	r.Entries = entries
+}
+
+// contains reports whether a YYYY-MM-DD date is within the range
+func (r *Range) contains(date string) bool {
+	if from := r.Params["from"]; from != "" && date < from {
+		return false
+	}
+	if to := r.Params["to"]; to != "" && date > to {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,222 @@
+package calendar
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"harvest-cli/pkg/config"
+	"harvest-cli/pkg/ics"
+)
+
+// DayOff represents a public holiday or a leave day
+type DayOff struct {
+	Date  time.Time
+	Name  string
+	Type  string
+	Hours float64 // Hours off, 0 means the whole day
+}
+
+// Calendar combines the work schedules with configured days off
+type Calendar struct {
+	config  *config.Config
+	daysOff map[string][]DayOff
+}
+
+// Period represents the capacity of a date range
+type Period struct {
+	From          time.Time
+	To            time.Time
+	ScheduledDays int     // Days with scheduled working hours
+	WorkingDays   float64 // Scheduled days minus holidays and leave
+	Capacity      float64 // Expected working hours
+	Holidays      []DayOff
+	Leave         []DayOff
+	HolidayDays   float64
+	LeaveDays     float64
+}
+
+// Load builds a calendar from the work schedules, holidays and holiday calendars in the configuration
+func Load(cfg *config.Config) (*Calendar, error) {
+	if err := cfg.ValidateWorkSchedules(); err != nil {
+		return nil, err
+	}
+
+	cal := &Calendar{
+		config:  cfg,
+		daysOff: make(map[string][]DayOff),
+	}
+
+	for _, holiday := range cfg.Holidays {
+		date, err := time.ParseInLocation("2006-01-02", holiday.Date, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday date: %s, expected YYYY-MM-DD", holiday.Date)
+		}
+
+		dayOffType, err := normalizeType(holiday.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		cal.add(DayOff{
+			Date:  date,
+			Name:  holiday.Name,
+			Type:  dayOffType,
+			Hours: holiday.Hours,
+		})
+	}
+
+	for _, source := range cfg.HolidayCalendars {
+		dayOffType, err := normalizeType(source.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		events, err := ics.ParseFile(source.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			if !event.AllDay {
+				// Timed events are partial days off
+				cal.add(DayOff{
+					Date:  truncateToDay(event.Start),
+					Name:  event.Summary,
+					Type:  dayOffType,
+					Hours: event.End.Sub(event.Start).Hours(),
+				})
+				continue
+			}
+
+			// All-day events end on the (exclusive) day after the last day off
+			for day := truncateToDay(event.Start); day.Before(event.End); day = day.AddDate(0, 0, 1) {
+				cal.add(DayOff{
+					Date: day,
+					Name: event.Summary,
+					Type: dayOffType,
+				})
+			}
+		}
+	}
+
+	return cal, nil
+}
+
+// add registers a day off, unless the same day off is already registered for the date,
+// e.g. by both the holidays and a holiday calendar
+func (c *Calendar) add(dayOff DayOff) {
+	key := dayOff.Date.Format("2006-01-02")
+	for _, existing := range c.daysOff[key] {
+		if existing.Type == dayOff.Type && existing.Hours == dayOff.Hours &&
+			strings.EqualFold(strings.TrimSpace(existing.Name), strings.TrimSpace(dayOff.Name)) {
+			return
+		}
+	}
+	c.daysOff[key] = append(c.daysOff[key], dayOff)
+}
+
+// DaysOff returns the holidays and leave registered for a date
+func (c *Calendar) DaysOff(date time.Time) []DayOff {
+	return c.daysOff[date.Format("2006-01-02")]
+}
+
+// ScheduledHours returns the working hours scheduled for a date, ignoring days off
+func (c *Calendar) ScheduledHours(date time.Time) float64 {
+	return c.config.GetScheduledHours(date)
+}
+
+// ExpectedHours returns the working hours expected on a date after days off
+func (c *Calendar) ExpectedHours(date time.Time) float64 {
+	scheduled := c.ScheduledHours(date)
+	return scheduled - c.hoursOff(date, scheduled)
+}
+
+// hoursOff returns the hours taken off on a date, capped at the scheduled hours
+func (c *Calendar) hoursOff(date time.Time, scheduled float64) float64 {
+	var hours float64
+	for _, dayOff := range c.DaysOff(date) {
+		if dayOff.Hours <= 0 {
+			return scheduled
+		}
+		hours += dayOff.Hours
+	}
+
+	if hours > scheduled {
+		return scheduled
+	}
+	return hours
+}
+
+// Period calculates the capacity between two dates (both inclusive)
+func (c *Calendar) Period(from, to time.Time) Period {
+	period := Period{
+		From: truncateToDay(from),
+		To:   truncateToDay(to),
+	}
+
+	for day := period.From; !day.After(period.To); day = day.AddDate(0, 0, 1) {
+		scheduled := c.ScheduledHours(day)
+		if scheduled <= 0 {
+			continue
+		}
+
+		period.ScheduledDays++
+		period.Capacity += scheduled
+		period.WorkingDays++
+
+		var holidayHours, leaveHours float64
+		for _, dayOff := range c.DaysOff(day) {
+			hours := dayOff.Hours
+			if hours <= 0 || hours > scheduled {
+				hours = scheduled
+			}
+
+			if dayOff.Type == config.DayOffLeave {
+				period.Leave = append(period.Leave, dayOff)
+				leaveHours += hours
+			} else {
+				period.Holidays = append(period.Holidays, dayOff)
+				holidayHours += hours
+			}
+		}
+
+		// At most one day is off, holidays come first and leave only counts for the rest of the day
+		holidayHours = math.Min(holidayHours, scheduled)
+		leaveHours = math.Min(leaveHours, scheduled-holidayHours)
+		period.HolidayDays += holidayHours / scheduled
+		period.LeaveDays += leaveHours / scheduled
+
+		hoursOff := c.hoursOff(day, scheduled)
+		period.Capacity -= hoursOff
+		period.WorkingDays -= hoursOff / scheduled
+	}
+
+	return period
+}
+
+// AverageDailyHours returns the average scheduled hours per working day in the period
+func (p Period) AverageDailyHours() float64 {
+	if p.WorkingDays <= 0 {
+		return 0
+	}
+	return p.Capacity / p.WorkingDays
+}
+
+// normalizeType validates a day off type, defaulting to a holiday
+func normalizeType(dayOffType string) (string, error) {
+	switch strings.ToLower(dayOffType) {
+	case "", config.DayOffHoliday:
+		return config.DayOffHoliday, nil
+	case config.DayOffLeave:
+		return config.DayOffLeave, nil
+	default:
+		return "", fmt.Errorf("invalid day off type: %s, expected %q or %q", dayOffType, config.DayOffHoliday, config.DayOffLeave)
+	}
+}
+
+// truncateToDay returns midnight of the given calendar date in the local time zone
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -5,21 +5,131 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Config represents the application configuration
 type Config struct {
-	Projects             []Project `json:"projects"`
-	DefaultProject       string    `json:"default_project,omitempty"`
-	DefaultTask          string    `json:"default_task,omitempty"`
-	YearStartDate        string    `json:"year_start_date,omitempty"`        // Format: "MM-DD", defaults to "01-01" if not specified
-	MonthlyCapacityHours float64   `json:"monthly_capacity_hours,omitempty"` // Default: 160 hours
-	BillableTaskIDs      []int     `json:"billable_task_ids,omitempty"`      // IDs of tasks considered billable for utilization calculation
-	HarvestAPI           APIConfig `json:"harvest_api"`
+	Projects               []Project           `json:"projects"`
+	DefaultProject         string              `json:"default_project,omitempty"`
+	DefaultTask            string              `json:"default_task,omitempty"`
+	YearStartDate          string              `json:"year_start_date,omitempty"`          // Format: "MM-DD", defaults to "01-01" if not specified
+	MonthlyCapacityHours   float64             `json:"monthly_capacity_hours,omitempty"`   // Deprecated: used to derive daily_hours when it is not set
+	DailyHours             float64             `json:"daily_hours,omitempty"`              // Default: 8 hours per working day when no work schedule is configured
+	BillableTaskIDs        []int               `json:"billable_task_ids,omitempty"`        // Overrides Harvest's billable flag with the IDs of tasks considered billable
+	Currency               string              `json:"currency,omitempty"`                 // Currency of billable amounts, defaults to "USD"
+	Holidays               []Holiday           `json:"holidays,omitempty"`                 // Public holidays and leave days excluded from capacity
+	HolidayCalendars       []HolidayCalendar   `json:"holiday_calendars,omitempty"`        // ICS files with additional holidays or leave days
+	WorkSchedules          []WorkSchedule      `json:"work_schedules,omitempty"`           // Hours per weekday, defaults to daily_hours Monday to Friday
+	BalanceStartDate       string              `json:"balance_start_date,omitempty"`       // Format: "YYYY-MM-DD", start of the time balance ledger
+	OpeningBalanceHours    float64             `json:"opening_balance_hours,omitempty"`    // Balance carried over from before balance_start_date
+	BalanceAdjustments     []BalanceAdjustment `json:"balance_adjustments,omitempty"`      // Manual corrections to the time balance
+	BudgetWarningThreshold float64             `json:"budget_warning_threshold,omitempty"` // Percentage of a project budget that triggers a warning in create, disabled if 0
+	DataDir                string              `json:"data_dir,omitempty"`                 // Directory for local data such as the undo journal, defaults to ~/.harvest-cli
+	CacheMaxAgeHours       float64             `json:"cache_max_age_hours,omitempty"`      // Age after which cached time entries are fetched again in full, defaults to 1 hour
+	GitAuthor              string              `json:"git_author,omitempty"`               // Author of commits used for notes, defaults to the user.email of each repository
+	RepoMappings           []RepoMapping       `json:"repo_mappings,omitempty"`            // Projects and tasks of git repositories
+	TicketRules            []TicketRule        `json:"ticket_rules,omitempty"`             // Patterns of ticket IDs linked to new time entries
+	CalendarFilters        []CalendarFilter    `json:"calendar_filters,omitempty"`         // Meetings to import or skip in import-calendar
+	CalendarRules          []CalendarRule      `json:"calendar_rules,omitempty"`           // Projects and tasks of meetings by title
+	HarvestAPI             APIConfig           `json:"harvest_api"`
+
+	path string // Path of the loaded configuration file
+}
+
+// Holiday represents a public holiday or a leave day in the configuration
+type Holiday struct {
+	Date  string  `json:"date"` // Format: "YYYY-MM-DD"
+	Name  string  `json:"name,omitempty"`
+	Type  string  `json:"type,omitempty"`  // "holiday" or "leave", defaults to "holiday"
+	Hours float64 `json:"hours,omitempty"` // Hours off for partial days, defaults to the full day
+}
+
+// HolidayCalendar represents an ICS file whose all-day events are days off
+type HolidayCalendar struct {
+	Path string `json:"path"`
+	Type string `json:"type,omitempty"` // "holiday" or "leave", defaults to "holiday"
+}
+
+// WorkSchedule represents the working hours per weekday from a given date
+type WorkSchedule struct {
+	EffectiveFrom string             `json:"effective_from,omitempty"` // Format: "YYYY-MM-DD", applies from the beginning if empty
+	Hours         map[string]float64 `json:"hours"`                    // Keyed by weekday name, e.g. "monday" or "mon"
+}
+
+// BalanceAdjustment represents a manual correction to the time balance
+type BalanceAdjustment struct {
+	Date   string  `json:"date"`  // Format: "YYYY-MM-DD"
+	Hours  float64 `json:"hours"` // Positive values add to the balance, negative values subtract
+	Reason string  `json:"reason,omitempty"`
+}
+
+// RepoMapping maps a git repository to a project and task
+type RepoMapping struct {
+	Path    string `json:"path"`    // Repository directory, may start with ~
+	Project string `json:"project"` // Project name as in projects
+	Task    string `json:"task,omitempty"`
+}
+
+// TicketRule describes how to find a ticket ID in notes or branch names and link to it
+type TicketRule struct {
+	Pattern   string `json:"pattern"`             // Regular expression of the ticket ID, the first group is used if there is one
+	Permalink string `json:"permalink,omitempty"` // URL of the ticket, {id} is replaced with the ticket ID
+	GroupID   string `json:"group_id,omitempty"`  // Group of the ticket in the external service, e.g. the project key
+	Prefix    string `json:"prefix,omitempty"`    // Prefix added to notes without the ticket ID, defaults to "{id} "
+
+	pattern *regexp.Regexp // Compiled when the configuration is loaded
+}
+
+// FindID returns the ticket ID matched by the rule in a text, or an empty string if there is none
+func (r *TicketRule) FindID(text string) string {
+	if r.pattern == nil {
+		return ""
+	}
+
+	match := r.pattern.FindStringSubmatch(text)
+	if match == nil {
+		return ""
+	}
+
+	// Use the first group if the pattern has one
+	if len(match) > 1 {
+		return match[1]
+	}
+	return match[0]
+}
+
+// CalendarFilter selects meetings by attendee or keyword. A meeting is imported if it matches
+// any filter that isn't excluding (or there are none) and no excluding filter.
+type CalendarFilter struct {
+	Attendee string `json:"attendee,omitempty"` // Email of an attendee who hasn't declined the meeting
+	Keyword  string `json:"keyword,omitempty"`  // Case-insensitive text in the title or description
+	Exclude  bool   `json:"exclude,omitempty"`  // Skip the matching meetings
+}
+
+// CalendarRule maps meetings whose title matches a pattern to a project and task
+type CalendarRule struct {
+	Pattern string `json:"pattern"` // Case-insensitive regular expression of the meeting title
+	Project string `json:"project"` // Project name as in projects
+	Task    string `json:"task,omitempty"`
+
+	pattern *regexp.Regexp // Compiled when the configuration is loaded
 }
 
+// Matches reports whether a meeting title matches the rule
+func (r *CalendarRule) Matches(title string) bool {
+	return r.pattern != nil && r.pattern.MatchString(title)
+}
+
+// Day off types
+const (
+	DayOffHoliday = "holiday"
+	DayOffLeave   = "leave"
+)
+
 // APIConfig represents the Harvest API configuration
 type APIConfig struct {
 	AccountID string `json:"account_id"`
@@ -80,7 +190,7 @@ func LoadConfig() (*Config, error) {
 	}
 	defer configFile.Close()
 
-	fmt.Printf("Using config file: %s\n", configPath)
+	fmt.Fprintf(os.Stderr, "Using config file: %s\n", configPath)
 
 	var config Config
 	decoder := json.NewDecoder(configFile)
@@ -88,6 +198,21 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to decode config.json: %w", err)
 	}
 
+	config.path = configPath
+
+	if err := config.compilePatterns(); err != nil {
+		return nil, err
+	}
+
+	if config.MonthlyCapacityHours > 0 {
+		if config.DailyHours > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: monthly_capacity_hours is deprecated and ignored because daily_hours is set\n")
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: monthly_capacity_hours is deprecated, using %.2f daily_hours derived from it; set daily_hours instead\n",
+				config.GetDailyHours())
+		}
+	}
+
 	// Set default base URL if not provided
 	if config.HarvestAPI.BaseURL == "" {
 		config.HarvestAPI.BaseURL = "https://api.harvestapp.com/v2"
@@ -96,6 +221,95 @@ func LoadConfig() (*Config, error) {
 	return &config, nil
 }
 
+// Path returns the path of the loaded configuration file
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Save writes the given top-level keys of the configuration, e.g. "balance_adjustments", back to
+// the file it was loaded from. Other keys, including unknown ones, are kept as they are in the
+// file, so defaults filled in at load time are not written.
+func (c *Config) Save(keys ...string) error {
+	if c.path == "" {
+		return fmt.Errorf("configuration was not loaded from a file")
+	}
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var file map[string]json.RawMessage
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	data, err = json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	// Empty values are left out of the file, like when encoding the whole configuration
+	for _, key := range keys {
+		if value, ok := values[key]; ok {
+			file[key] = value
+		} else {
+			delete(file, key)
+		}
+	}
+
+	data, err = json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// GetDataDir returns the directory for local data, creating it if it doesn't exist
+func (c *Config) GetDataDir() (string, error) {
+	dataDir := c.DataDir
+	if dataDir == "" || strings.HasPrefix(dataDir, "~") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home directory: %w", err)
+		}
+
+		if dataDir == "" {
+			dataDir = filepath.Join(homeDir, ".harvest-cli")
+		} else {
+			dataDir = filepath.Join(homeDir, strings.TrimPrefix(dataDir, "~"))
+		}
+	}
+
+	if err := os.MkdirAll(dataDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	return dataDir, nil
+}
+
+// GetBalanceStartDate returns the configured start of the time balance ledger
+func (c *Config) GetBalanceStartDate() (time.Time, error) {
+	if c.BalanceStartDate == "" {
+		return time.Time{}, fmt.Errorf("balance_start_date is not configured")
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", c.BalanceStartDate, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid balance_start_date format: %s, expected YYYY-MM-DD", c.BalanceStartDate)
+	}
+
+	return date, nil
+}
+
 // GetProjectByName returns a project by its name
 func (c *Config) GetProjectByName(name string) *Project {
 	for i, project := range c.Projects {
@@ -136,6 +350,32 @@ func (p *Project) GetTaskByID(id int) *Task {
 	return nil
 }
 
+// GetRepoMapping returns the mapping of a repository directory
+func (c *Config) GetRepoMapping(repoPath string) *RepoMapping {
+	for i, mapping := range c.RepoMappings {
+		path, err := ExpandPath(mapping.Path)
+		if err != nil {
+			continue
+		}
+		if filepath.Clean(path) == filepath.Clean(repoPath) {
+			return &c.RepoMappings[i]
+		}
+	}
+	return nil
+}
+
+// ExpandPath expands a leading ~ to the user's home directory and makes the path absolute
+func ExpandPath(path string) (string, error) {
+	if strings.HasPrefix(path, "~") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home directory: %w", err)
+		}
+		path = filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+	}
+	return filepath.Abs(path)
+}
+
 // GetDefaultProject returns the default project
 func (c *Config) GetDefaultProject() *Project {
 	if c.DefaultProject == "" {
@@ -179,6 +419,8 @@ func (c *Config) GetYearStartDate() (int, int, error) {
 }
 
 // GetMonthlyCapacityHours returns the configured monthly capacity hours or default value of 160
+//
+// Deprecated: capacity is calculated from working days, see GetDailyHours.
 func (c *Config) GetMonthlyCapacityHours() float64 {
 	if c.MonthlyCapacityHours <= 0 {
 		return 160.0 // Default monthly capacity is 160 hours
@@ -186,6 +428,144 @@ func (c *Config) GetMonthlyCapacityHours() float64 {
 	return c.MonthlyCapacityHours
 }
 
+// workingDaysPerMonth is the average number of weekdays in a month (52 weeks of 5 days over 12 months)
+const workingDaysPerMonth = 52.0 * 5 / 12
+
+// GetDailyHours returns the configured hours per working day or default value of 8.
+// Configurations that only set the deprecated monthly_capacity_hours get the daily hours
+// that give the same capacity over a year.
+func (c *Config) GetDailyHours() float64 {
+	if c.DailyHours > 0 {
+		return c.DailyHours
+	}
+	if c.MonthlyCapacityHours > 0 {
+		return c.MonthlyCapacityHours / workingDaysPerMonth
+	}
+	return 8.0 // Default working day is 8 hours
+}
+
+// GetCacheMaxAge returns how long cached time entries are refreshed incrementally, defaults to 1 hour.
+// Deleted entries are only noticed by a full fetch, so they are shown for at most this long.
+func (c *Config) GetCacheMaxAge() time.Duration {
+	if c.CacheMaxAgeHours <= 0 {
+		return time.Hour
+	}
+	return time.Duration(c.CacheMaxAgeHours * float64(time.Hour))
+}
+
+// compilePatterns compiles the regular expressions of the rules once, so invalid ones are reported at load
+func (c *Config) compilePatterns() error {
+	for i := range c.CalendarRules {
+		rule := &c.CalendarRules[i]
+		pattern, err := regexp.Compile("(?i)" + rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern in calendar_rules: %q: %w", rule.Pattern, err)
+		}
+		rule.pattern = pattern
+	}
+
+	for i := range c.TicketRules {
+		rule := &c.TicketRules[i]
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern in ticket_rules: %q: %w", rule.Pattern, err)
+		}
+		rule.pattern = pattern
+	}
+
+	return nil
+}
+
+// ValidateWorkSchedules checks the effective dates and weekday names of all work schedules
+func (c *Config) ValidateWorkSchedules() error {
+	for _, schedule := range c.WorkSchedules {
+		if schedule.EffectiveFrom != "" {
+			if _, err := time.Parse("2006-01-02", schedule.EffectiveFrom); err != nil {
+				return fmt.Errorf("invalid effective_from in work_schedules: %s, expected YYYY-MM-DD", schedule.EffectiveFrom)
+			}
+		}
+
+		for day, hours := range schedule.Hours {
+			if _, ok := parseWeekday(day); !ok {
+				return fmt.Errorf("invalid weekday in work_schedules: %s", day)
+			}
+			if hours < 0 || hours > 24 {
+				return fmt.Errorf("invalid hours for %s in work_schedules: %.2f", day, hours)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetScheduledHours returns the working hours scheduled for a date.
+// Without a work schedule, every weekday from Monday to Friday has daily_hours.
+func (c *Config) GetScheduledHours(date time.Time) float64 {
+	schedule := c.GetWorkSchedule(date)
+	if schedule == nil {
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			return 0
+		}
+		return c.GetDailyHours()
+	}
+
+	for day, hours := range schedule.Hours {
+		if weekday, ok := parseWeekday(day); ok && weekday == date.Weekday() {
+			return hours
+		}
+	}
+	return 0
+}
+
+// GetWorkSchedule returns the work schedule in effect on a date, or nil if none applies
+func (c *Config) GetWorkSchedule(date time.Time) *WorkSchedule {
+	var current *WorkSchedule
+	var currentFrom string
+
+	day := date.Format("2006-01-02")
+	for i, schedule := range c.WorkSchedules {
+		// Dates in YYYY-MM-DD format compare chronologically as strings
+		if schedule.EffectiveFrom > day {
+			continue
+		}
+		if current == nil || schedule.EffectiveFrom >= currentFrom {
+			current = &c.WorkSchedules[i]
+			currentFrom = schedule.EffectiveFrom
+		}
+	}
+
+	return current
+}
+
+// parseWeekday parses full or abbreviated English weekday names
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		full := strings.ToLower(weekday.String())
+		if name == full || name == full[:3] {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+// GetCurrency returns the configured currency or default value of USD
+func (c *Config) GetCurrency() string {
+	if c.Currency == "" {
+		return "USD"
+	}
+	return c.Currency
+}
+
+// IsBillableEntry checks if a time entry counts as billable for utilization.
+// The billable_task_ids list overrides Harvest's billable flag when configured.
+func (c *Config) IsBillableEntry(taskID int, harvestBillable bool) bool {
+	if len(c.BillableTaskIDs) == 0 {
+		return harvestBillable
+	}
+	return c.IsBillableTask(taskID)
+}
+
 // IsBillableTask checks if a task ID is in the list of billable task IDs
 func (c *Config) IsBillableTask(taskID int) bool {
 	// If no billable tasks are defined, consider all tasks billable
@@ -0,0 +1,177 @@
+package gitlog
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Commits further apart than SessionGap start a new work session
+const SessionGap = 2 * time.Hour
+
+// SessionLeadTime is the time assumed to be spent before the first commit of a session
+const SessionLeadTime = 30 * time.Minute
+
+// Commit represents a commit in a git repository
+type Commit struct {
+	Repo    string    `json:"repo"` // Name of the repository directory
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email"`
+	Time    time.Time `json:"time"`
+	Subject string    `json:"subject"`
+}
+
+// fieldSeparator separates the fields of a commit in the log output
+const fieldSeparator = "\x1f"
+
+// logFormat is the log format of a commit: hash, author name, author email, author date and subject
+var logFormat = strings.Join([]string{"%H", "%an", "%ae", "%aI", "%s"}, fieldSeparator)
+
+// TopLevel returns the root directory of the repository containing a path
+func TopLevel(path string) (string, error) {
+	out, err := git(path, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Clean(out), nil
+}
+
+// HooksDir returns the directory of the hooks of a repository
+func HooksDir(repo string) (string, error) {
+	dir, err := git(repo, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(repo, dir)
+	}
+	return dir, nil
+}
+
+// UserEmail returns the configured git user email of a repository
+func UserEmail(repo string) (string, error) {
+	return git(repo, "config", "user.email")
+}
+
+// CurrentBranch returns the name of the checked out branch of a repository, or "HEAD" if it is detached
+func CurrentBranch(repo string) (string, error) {
+	return git(repo, "rev-parse", "--abbrev-ref", "HEAD")
+}
+
+// Commits returns the non-merge commits on any branch of a repository by an author
+// (name or email pattern) between two times, oldest first
+func Commits(repo, author string, since, until time.Time) ([]Commit, error) {
+	args := []string{
+		"--all", "--no-merges",
+		"--since=" + since.Format(time.RFC3339),
+		"--until=" + until.Format(time.RFC3339),
+	}
+	if author != "" {
+		args = append(args, "--author="+author)
+	}
+
+	return Log(repo, args...)
+}
+
+// Log returns the commits listed by git log with the given arguments, oldest first
+func Log(repo string, args ...string) ([]Commit, error) {
+	out, err := git(repo, append([]string{"log", "--format=" + logFormat}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(repo)
+	var commits []Commit
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+
+		commit, err := parseCommit(name, line)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+	}
+
+	sort.Slice(commits, func(i, j int) bool {
+		return commits[i].Time.Before(commits[j].Time)
+	})
+
+	return commits, nil
+}
+
+// parseCommit parses a line of log output
+func parseCommit(repo, line string) (Commit, error) {
+	fields := strings.SplitN(line, fieldSeparator, 5)
+	if len(fields) != 5 {
+		return Commit{}, fmt.Errorf("unexpected git log output: %q", line)
+	}
+
+	commitTime, err := time.Parse(time.RFC3339, fields[3])
+	if err != nil {
+		return Commit{}, fmt.Errorf("invalid commit time %q: %w", fields[3], err)
+	}
+
+	return Commit{
+		Repo:    repo,
+		Hash:    fields[0],
+		Author:  fields[1],
+		Email:   fields[2],
+		Time:    commitTime,
+		Subject: fields[4],
+	}, nil
+}
+
+// EstimateDuration estimates the time worked from commit times. Commits less than
+// SessionGap apart belong to the same session, and each session is counted from
+// SessionLeadTime before its first commit to its last commit.
+func EstimateDuration(commits []Commit) time.Duration {
+	if len(commits) == 0 {
+		return 0
+	}
+
+	times := make([]time.Time, len(commits))
+	for i, commit := range commits {
+		times[i] = commit.Time
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	total := SessionLeadTime
+	for i := 1; i < len(times); i++ {
+		gap := times[i].Sub(times[i-1])
+		if gap < SessionGap {
+			total += gap
+		} else {
+			total += SessionLeadTime
+		}
+	}
+
+	return total
+}
+
+// git runs a git command in a repository and returns its trimmed output
+func git(repo string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return "", fmt.Errorf("git %s failed in %s: %s", args[0], repo, message)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
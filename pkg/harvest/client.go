@@ -3,12 +3,20 @@ package harvest
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"harvest-cli/pkg/audit"
 	"harvest-cli/pkg/config"
 )
 
@@ -18,6 +26,9 @@ type Client struct {
 	baseURL    string
 	accountID  string
 	token      string
+	auditLog   *audit.Log  // Records time entry mutations when set
+	warnings   io.Writer   // Receives warnings that don't fail a request, os.Stderr by default
+	offline    atomic.Bool // Set once a request fails to connect to Harvest
 }
 
 // TimeEntry represents a time entry in Harvest
@@ -27,21 +38,169 @@ type TimeEntry struct {
 	ProjectID      int            `json:"project_id"`
 	TaskID         int            `json:"task_id"`
 	Hours          float64        `json:"hours"`
+	StartedTime    string         `json:"started_time,omitempty"` // Wall clock start, e.g. "8:00am"
+	EndedTime      string         `json:"ended_time,omitempty"`   // Wall clock end, e.g. "12:30pm"
 	Notes          string         `json:"notes,omitempty"`
+	Billable       bool           `json:"billable,omitempty"`
+	BillableRate   *float64       `json:"billable_rate,omitempty"`
+	CostRate       *float64       `json:"cost_rate,omitempty"`
+	Budgeted       bool           `json:"budgeted,omitempty"`
 	CreatedAt      time.Time      `json:"created_at,omitempty"`
 	UpdatedAt      time.Time      `json:"updated_at,omitempty"`
 	IsRunning      bool           `json:"is_running,omitempty"`
+	IsLocked       bool           `json:"is_locked,omitempty"`
+	LockedReason   string         `json:"locked_reason,omitempty"`
+	ApprovalStatus string         `json:"approval_status,omitempty"` // "unsubmitted", "submitted" or "approved"
 	User           User           `json:"user,omitempty"`
 	UserID         int64          `json:"user_id,omitempty"`
 	UserAssignment UserAssignment `json:"user_assignment,omitempty"`
 	Project        Project        `json:"project,omitempty"`
 	Task           Task           `json:"task,omitempty"`
+
+	ExternalReference *ExternalReference `json:"external_reference,omitempty"` // Link to an issue in another service
+}
+
+// ExternalReference links a time entry to an item in another service, such as an issue tracker
+type ExternalReference struct {
+	ID        string `json:"id"`
+	GroupID   string `json:"group_id,omitempty"`
+	AccountID string `json:"account_id,omitempty"`
+	Permalink string `json:"permalink"`
+	Service   string `json:"service,omitempty"`
+}
+
+// TimeEntryUpdate holds the fields of a time entry to change, nil fields are left unchanged
+type TimeEntryUpdate struct {
+	SpentDate   *string  `json:"spent_date,omitempty"`
+	ProjectID   *int     `json:"project_id,omitempty"`
+	TaskID      *int     `json:"task_id,omitempty"`
+	Hours       *float64 `json:"hours,omitempty"`
+	StartedTime *string  `json:"started_time,omitempty"`
+	EndedTime   *string  `json:"ended_time,omitempty"`
+	Notes       *string  `json:"notes,omitempty"`
+
+	ExternalReference *ExternalReference `json:"external_reference,omitempty"`
+}
+
+// IsEmpty reports whether the update changes no fields
+func (u *TimeEntryUpdate) IsEmpty() bool {
+	return u.SpentDate == nil && u.ProjectID == nil && u.TaskID == nil && u.Hours == nil &&
+		u.StartedTime == nil && u.EndedTime == nil && u.Notes == nil && u.ExternalReference == nil
+}
+
+// DiffTimeEntry returns an update with the fields of the desired entry that differ from the
+// current one. An empty date, project or task and a nil external reference are left unchanged,
+// and durations are compared to the minute.
+func DiffTimeEntry(current, desired *TimeEntry) *TimeEntryUpdate {
+	update := &TimeEntryUpdate{}
+
+	if desired.SpentDate != "" && desired.SpentDate != current.SpentDate {
+		update.SpentDate = &desired.SpentDate
+	}
+	if projectID := desired.projectID(); projectID != 0 && projectID != current.projectID() {
+		update.ProjectID = &projectID
+	}
+	if taskID := desired.taskID(); taskID != 0 && taskID != current.taskID() {
+		update.TaskID = &taskID
+	}
+	if math.Round(desired.Hours*60) != math.Round(current.Hours*60) {
+		update.Hours = &desired.Hours
+	}
+	if desired.StartedTime != current.StartedTime {
+		update.StartedTime = &desired.StartedTime
+	}
+	if desired.EndedTime != current.EndedTime {
+		update.EndedTime = &desired.EndedTime
+	}
+	if desired.Notes != current.Notes {
+		update.Notes = &desired.Notes
+	}
+	if desired.ExternalReference != nil && (current.ExternalReference == nil || *desired.ExternalReference != *current.ExternalReference) {
+		update.ExternalReference = desired.ExternalReference
+	}
+
+	return update
+}
+
+// projectID returns the project ID of a request or of an entry returned by the API
+func (e *TimeEntry) projectID() int {
+	if e.ProjectID != 0 {
+		return e.ProjectID
+	}
+	return int(e.Project.ID)
+}
+
+// taskID returns the task ID of a request or of an entry returned by the API
+func (e *TimeEntry) taskID() int {
+	if e.TaskID != 0 {
+		return e.TaskID
+	}
+	return int(e.Task.ID)
+}
+
+// BillableAmount returns the billable amount of the entry, or 0 if it is not billable
+func (e *TimeEntry) BillableAmount() float64 {
+	if !e.Billable || e.BillableRate == nil {
+		return 0
+	}
+	return e.Hours * *e.BillableRate
+}
+
+// ClockRange returns the wall clock start and end of the entry as times of day on January 1st,
+// year 0, or false if the entry has no valid start and end time
+func (e *TimeEntry) ClockRange() (time.Time, time.Time, bool) {
+	if e.StartedTime == "" || e.EndedTime == "" {
+		return time.Time{}, time.Time{}, false
+	}
+
+	start, err := ParseClockTime(e.StartedTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	end, err := ParseClockTime(e.EndedTime)
+	if err != nil || end.Before(start) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	return start, end, true
+}
+
+// clockLayouts are the accepted formats of wall clock times
+var clockLayouts = []string{"15:04", "3:04pm", "3:04PM", "3:04 pm", "3:04 PM", "3pm", "3PM"}
+
+// ParseClockTime parses a wall clock time such as "09:00", "9:00am" or "9am"
+func ParseClockTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range clockLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected HH:MM or H:MMam/pm", value)
+}
+
+// FormatClockTime formats a wall clock time the way Harvest does, e.g. "8:00am"
+func FormatClockTime(t time.Time) string {
+	return t.Format("3:04pm")
 }
 
 // User represents a user in Harvest
 type User struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID             int64  `json:"id"`
+	Name           string `json:"name,omitempty"`
+	FirstName      string `json:"first_name,omitempty"`
+	LastName       string `json:"last_name,omitempty"`
+	Email          string `json:"email,omitempty"`
+	IsActive       bool   `json:"is_active,omitempty"`
+	WeeklyCapacity int    `json:"weekly_capacity,omitempty"` // In seconds
+}
+
+// FullName returns the display name of the user
+func (u *User) FullName() string {
+	if u.Name != "" {
+		return u.Name
+	}
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
 }
 
 // UserAssignment represents a user assignment in Harvest
@@ -73,11 +232,55 @@ type TimeEntriesResponse struct {
 	Page         int         `json:"page"`
 }
 
+// Pagination represents the paging fields shared by Harvest list responses
+type Pagination struct {
+	PerPage      int  `json:"per_page"`
+	TotalPages   int  `json:"total_pages"`
+	TotalEntries int  `json:"total_entries"`
+	NextPage     *int `json:"next_page"`
+	PreviousPage *int `json:"previous_page"`
+	Page         int  `json:"page"`
+}
+
 // ErrorResponse represents an error response from the Harvest API
 type ErrorResponse struct {
 	Message string `json:"message"`
 }
 
+// APIError represents an error status returned by the Harvest API
+type APIError struct {
+	Message    string
+	StatusCode int
+}
+
+// Error returns the error message with the status code
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: %s (status code: %d)", e.Message, e.StatusCode)
+}
+
+// IsNotFound reports whether the API returned 404 Not Found
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsRateLimited reports whether the API returned 429 Too Many Requests
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// IsNetworkError reports whether a request failed because Harvest could not be reached.
+// Only failures to connect count: after a timeout the request may already have been applied.
+func IsNetworkError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
 // NewClient creates a new Harvest API client
 func NewClient(cfg *config.APIConfig) *Client {
 	return &Client{
@@ -87,9 +290,34 @@ func NewClient(cfg *config.APIConfig) *Client {
 		baseURL:   cfg.BaseURL,
 		accountID: cfg.AccountID,
 		token:     cfg.Token,
+		warnings:  os.Stderr,
 	}
 }
 
+// Offline reports whether an earlier request of the client failed to connect to Harvest
+func (c *Client) Offline() bool {
+	return c.offline.Load()
+}
+
+// send sends a request and remembers connection failures, see Offline
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if IsNetworkError(err) {
+		c.offline.Store(true)
+	}
+	return resp, err
+}
+
+// SetWarningOutput sends the warnings of the client, such as audit log failures, to a writer
+func (c *Client) SetWarningOutput(w io.Writer) {
+	c.warnings = w
+}
+
+// SetAuditLog records all time entry mutations sent by the client in the audit log
+func (c *Client) SetAuditLog(auditLog *audit.Log) {
+	c.auditLog = auditLog
+}
+
 // CreateTimeEntry creates a new time entry in Harvest
 func (c *Client) CreateTimeEntry(entry *TimeEntry) (*TimeEntry, error) {
 	url := fmt.Sprintf("%s/time_entries", c.baseURL)
@@ -111,8 +339,9 @@ func (c *Client) CreateTimeEntry(entry *TimeEntry) (*TimeEntry, error) {
 	req.Header.Set("Harvest-Account-ID", c.accountID)
 	req.Header.Set("User-Agent", "Harvest CLI Utility")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
+		c.recordAudit("create", req, 0, body, 0, nil, err)
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
@@ -120,8 +349,10 @@ func (c *Client) CreateTimeEntry(entry *TimeEntry) (*TimeEntry, error) {
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
+		c.recordAudit("create", req, 0, body, resp.StatusCode, nil, err)
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
+	c.recordAudit("create", req, 0, body, resp.StatusCode, respBody, nil)
 
 	// Check for error response
 	if resp.StatusCode >= 400 {
@@ -129,7 +360,7 @@ func (c *Client) CreateTimeEntry(entry *TimeEntry) (*TimeEntry, error) {
 		if err := json.Unmarshal(respBody, &errResp); err != nil {
 			return nil, fmt.Errorf("failed to parse error response: %w", err)
 		}
-		return nil, fmt.Errorf("API error: %s (status code: %d)", errResp.Message, resp.StatusCode)
+		return nil, &APIError{Message: errResp.Message, StatusCode: resp.StatusCode}
 	}
 
 	// Parse response
@@ -143,6 +374,39 @@ func (c *Client) CreateTimeEntry(entry *TimeEntry) (*TimeEntry, error) {
 
 // GetTimeEntries retrieves time entries from Harvest based on the provided parameters
 func (c *Client) GetTimeEntries(params map[string]string) ([]TimeEntry, error) {
+	response, err := c.getTimeEntriesPage(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.TimeEntries, nil
+}
+
+// GetAllTimeEntries retrieves time entries from every page of the result set
+func (c *Client) GetAllTimeEntries(params map[string]string) ([]TimeEntry, error) {
+	// Copy the parameters so the page number does not leak to the caller
+	pageParams := CopyParams(params)
+
+	var timeEntries []TimeEntry
+	page := 1
+	for {
+		pageParams["page"] = strconv.Itoa(page)
+
+		response, err := c.getTimeEntriesPage(pageParams)
+		if err != nil {
+			return nil, err
+		}
+		timeEntries = append(timeEntries, response.TimeEntries...)
+
+		if response.NextPage == nil {
+			return timeEntries, nil
+		}
+		page = *response.NextPage
+	}
+}
+
+// getTimeEntriesPage retrieves a single page of time entries
+func (c *Client) getTimeEntriesPage(params map[string]string) (*TimeEntriesResponse, error) {
 	baseURL := fmt.Sprintf("%s/time_entries", c.baseURL)
 
 	// Add query parameters
@@ -165,7 +429,7 @@ func (c *Client) GetTimeEntries(params map[string]string) ([]TimeEntry, error) {
 	req.Header.Set("Harvest-Account-ID", c.accountID)
 	req.Header.Set("User-Agent", "Harvest CLI Utility")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -183,7 +447,7 @@ func (c *Client) GetTimeEntries(params map[string]string) ([]TimeEntry, error) {
 		if err := json.Unmarshal(respBody, &errResp); err != nil {
 			return nil, fmt.Errorf("failed to parse error response: %w", err)
 		}
-		return nil, fmt.Errorf("API error: %s (status code: %d)", errResp.Message, resp.StatusCode)
+		return nil, &APIError{Message: errResp.Message, StatusCode: resp.StatusCode}
 	}
 
 	// Parse response
@@ -192,7 +456,7 @@ func (c *Client) GetTimeEntries(params map[string]string) ([]TimeEntry, error) {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return response.TimeEntries, nil
+	return &response, nil
 }
 
 // GetTimeEntry retrieves a specific time entry by ID
@@ -210,7 +474,7 @@ func (c *Client) GetTimeEntry(id int64) (*TimeEntry, error) {
 	req.Header.Set("Harvest-Account-ID", c.accountID)
 	req.Header.Set("User-Agent", "Harvest CLI Utility")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -228,7 +492,7 @@ func (c *Client) GetTimeEntry(id int64) (*TimeEntry, error) {
 		if err := json.Unmarshal(respBody, &errResp); err != nil {
 			return nil, fmt.Errorf("failed to parse error response: %w", err)
 		}
-		return nil, fmt.Errorf("API error: %s (status code: %d)", errResp.Message, resp.StatusCode)
+		return nil, &APIError{Message: errResp.Message, StatusCode: resp.StatusCode}
 	}
 
 	// Parse response
@@ -254,34 +518,38 @@ func (c *Client) DeleteTimeEntry(id int64) error {
 	req.Header.Set("Harvest-Account-ID", c.accountID)
 	req.Header.Set("User-Agent", "Harvest CLI Utility")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
+		c.recordAudit("delete", req, id, nil, 0, nil, err)
 		return fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.recordAudit("delete", req, id, nil, resp.StatusCode, nil, err)
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	c.recordAudit("delete", req, id, nil, resp.StatusCode, respBody, nil)
+
 	// Check for error response
 	if resp.StatusCode >= 400 {
-		respBody, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("failed to read error response body: %w", err)
-		}
-
 		var errResp ErrorResponse
 		if err := json.Unmarshal(respBody, &errResp); err != nil {
 			return fmt.Errorf("failed to parse error response: %w", err)
 		}
-		return fmt.Errorf("API error: %s (status code: %d)", errResp.Message, resp.StatusCode)
+		return &APIError{Message: errResp.Message, StatusCode: resp.StatusCode}
 	}
 
 	return nil
 }
 
-// UpdateTimeEntry updates an existing time entry
-func (c *Client) UpdateTimeEntry(id int64, entry *TimeEntry) (*TimeEntry, error) {
+// UpdateTimeEntry changes the fields of an existing time entry that are set in the update
+func (c *Client) UpdateTimeEntry(id int64, update *TimeEntryUpdate) (*TimeEntry, error) {
 	url := fmt.Sprintf("%s/time_entries/%d", c.baseURL, id)
 
-	body, err := json.Marshal(entry)
+	body, err := json.Marshal(update)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal time entry: %w", err)
 	}
@@ -298,8 +566,9 @@ func (c *Client) UpdateTimeEntry(id int64, entry *TimeEntry) (*TimeEntry, error)
 	req.Header.Set("Harvest-Account-ID", c.accountID)
 	req.Header.Set("User-Agent", "Harvest CLI Utility")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
+		c.recordAudit("update", req, id, body, 0, nil, err)
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
@@ -307,8 +576,10 @@ func (c *Client) UpdateTimeEntry(id int64, entry *TimeEntry) (*TimeEntry, error)
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
+		c.recordAudit("update", req, id, body, resp.StatusCode, nil, err)
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
+	c.recordAudit("update", req, id, body, resp.StatusCode, respBody, nil)
 
 	// Check for error response
 	if resp.StatusCode >= 400 {
@@ -316,7 +587,7 @@ func (c *Client) UpdateTimeEntry(id int64, entry *TimeEntry) (*TimeEntry, error)
 		if err := json.Unmarshal(respBody, &errResp); err != nil {
 			return nil, fmt.Errorf("failed to parse error response: %w", err)
 		}
-		return nil, fmt.Errorf("API error: %s (status code: %d)", errResp.Message, resp.StatusCode)
+		return nil, &APIError{Message: errResp.Message, StatusCode: resp.StatusCode}
 	}
 
 	// Parse response
@@ -327,3 +598,142 @@ func (c *Client) UpdateTimeEntry(id int64, entry *TimeEntry) (*TimeEntry, error)
 
 	return &timeEntry, nil
 }
+
+// recordAudit records a time entry mutation in the audit log, if one is set.
+// Failing to write the audit log only prints a warning so the result of the request is not lost.
+func (c *Client) recordAudit(action string, req *http.Request, entryID int64, requestBody []byte, status int, responseBody []byte, requestErr error) {
+	if c.auditLog == nil {
+		return
+	}
+
+	entry := audit.Entry{
+		Profile:  c.accountID,
+		Action:   action,
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		EntryID:  entryID,
+		Request:  audit.Body(requestBody),
+		Response: audit.Body(responseBody),
+		Status:   status,
+	}
+	if requestErr != nil {
+		entry.Error = requestErr.Error()
+	}
+
+	// Use the ID of created entries
+	if entryID == 0 && status < 400 && len(responseBody) > 0 {
+		var created TimeEntry
+		if err := json.Unmarshal(responseBody, &created); err == nil {
+			entry.EntryID = created.ID
+		}
+	}
+
+	if err := c.auditLog.Record(entry); err != nil {
+		fmt.Fprintf(c.warnings, "Warning: failed to record %s request in the audit log: %v\n", action, err)
+	}
+}
+
+// get sends a GET request to the given API path and decodes the JSON response into out
+func (c *Client) get(path string, params map[string]string, out interface{}) error {
+	requestURL := fmt.Sprintf("%s%s", c.baseURL, path)
+
+	// Add query parameters
+	if len(params) > 0 {
+		query := url.Values{}
+		for key, value := range params {
+			query.Add(key, value)
+		}
+		requestURL = fmt.Sprintf("%s?%s", requestURL, query.Encode())
+	}
+
+	req, err := http.NewRequest("GET", requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	return c.do(req, out)
+}
+
+// do sets the authentication headers, sends the request and decodes the JSON response into out
+func (c *Client) do(req *http.Request, out interface{}) error {
+	// Set headers
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Harvest-Account-ID", c.accountID)
+	req.Header.Set("User-Agent", "Harvest CLI Utility")
+
+	resp, err := c.send(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check for error response
+	if resp.StatusCode >= 400 {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err != nil {
+			return fmt.Errorf("failed to parse error response: %w", err)
+		}
+		return &APIError{Message: errResp.Message, StatusCode: resp.StatusCode}
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	// Parse response
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
+
+// getAllPages retrieves the items stored under key from every page of a list endpoint
+func getAllPages[T any](c *Client, path, key string, params map[string]string) ([]T, error) {
+	pageParams := CopyParams(params)
+
+	var items []T
+	page := 1
+	for {
+		pageParams["page"] = strconv.Itoa(page)
+
+		var response map[string]json.RawMessage
+		if err := c.get(path, pageParams, &response); err != nil {
+			return nil, err
+		}
+
+		var pageItems []T
+		if err := json.Unmarshal(response[key], &pageItems); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		items = append(items, pageItems...)
+
+		var nextPage *int
+		if raw, ok := response["next_page"]; ok {
+			if err := json.Unmarshal(raw, &nextPage); err != nil {
+				return nil, fmt.Errorf("failed to parse response: %w", err)
+			}
+		}
+		if nextPage == nil {
+			return items, nil
+		}
+		page = *nextPage
+	}
+}
+
+// CopyParams copies query parameters so that adding parameters, such as the page, does not
+// modify the caller's map
+func CopyParams(params map[string]string) map[string]string {
+	copied := make(map[string]string, len(params)+1)
+	for key, value := range params {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,135 @@
+package harvest
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ptr returns a pointer to a value for the expected updates
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func TestDiffTimeEntry(t *testing.T) {
+	// An entry as returned by the API, with the project and task as objects
+	current := TimeEntry{
+		ID:          1,
+		SpentDate:   "2024-03-04",
+		Hours:       1.5,
+		StartedTime: "9:00am",
+		EndedTime:   "10:30am",
+		Notes:       "Code review",
+		Project:     Project{ID: 10, Name: "Project A"},
+		Task:        Task{ID: 100, Name: "Development"},
+		ExternalReference: &ExternalReference{
+			ID:        "ABC-1",
+			Permalink: "https://example.com/ABC-1",
+		},
+	}
+
+	// request returns a request with the values of the current entry after a change
+	request := func(change func(*TimeEntry)) *TimeEntry {
+		desired := current
+		desired.ProjectID = int(current.Project.ID)
+		desired.TaskID = int(current.Task.ID)
+		reference := *current.ExternalReference
+		desired.ExternalReference = &reference
+		change(&desired)
+		return &desired
+	}
+
+	tests := []struct {
+		name    string
+		desired *TimeEntry
+		want    *TimeEntryUpdate
+	}{
+		{
+			name:    "unchanged",
+			desired: request(func(e *TimeEntry) {}),
+			want:    &TimeEntryUpdate{},
+		},
+		{
+			name:    "date",
+			desired: request(func(e *TimeEntry) { e.SpentDate = "2024-03-05" }),
+			want:    &TimeEntryUpdate{SpentDate: ptr("2024-03-05")},
+		},
+		{
+			name:    "project",
+			desired: request(func(e *TimeEntry) { e.ProjectID = 11 }),
+			want:    &TimeEntryUpdate{ProjectID: ptr(11)},
+		},
+		{
+			name:    "task",
+			desired: request(func(e *TimeEntry) { e.TaskID = 101 }),
+			want:    &TimeEntryUpdate{TaskID: ptr(101)},
+		},
+		{
+			name:    "hours",
+			desired: request(func(e *TimeEntry) { e.Hours = 2 }),
+			want:    &TimeEntryUpdate{Hours: ptr(2.0)},
+		},
+		{
+			name:    "hours within the same minute",
+			desired: request(func(e *TimeEntry) { e.Hours = 1.5001 }),
+			want:    &TimeEntryUpdate{},
+		},
+		{
+			name:    "start time",
+			desired: request(func(e *TimeEntry) { e.StartedTime = "8:30am" }),
+			want:    &TimeEntryUpdate{StartedTime: ptr("8:30am")},
+		},
+		{
+			name:    "end time",
+			desired: request(func(e *TimeEntry) { e.EndedTime = "11:00am" }),
+			want:    &TimeEntryUpdate{EndedTime: ptr("11:00am")},
+		},
+		{
+			name:    "notes",
+			desired: request(func(e *TimeEntry) { e.Notes = "Pairing" }),
+			want:    &TimeEntryUpdate{Notes: ptr("Pairing")},
+		},
+		{
+			name:    "external reference",
+			desired: request(func(e *TimeEntry) { e.ExternalReference.ID = "ABC-2" }),
+			want: &TimeEntryUpdate{ExternalReference: &ExternalReference{
+				ID:        "ABC-2",
+				Permalink: "https://example.com/ABC-1",
+			}},
+		},
+		{
+			name:    "cleared notes",
+			desired: request(func(e *TimeEntry) { e.Notes = "" }),
+			want:    &TimeEntryUpdate{Notes: ptr("")},
+		},
+		{
+			name: "cleared start and end times",
+			desired: request(func(e *TimeEntry) {
+				e.StartedTime = ""
+				e.EndedTime = ""
+			}),
+			want: &TimeEntryUpdate{StartedTime: ptr(""), EndedTime: ptr("")},
+		},
+		{
+			name: "empty date, project, task and external reference are kept",
+			desired: request(func(e *TimeEntry) {
+				e.SpentDate = ""
+				e.ProjectID = 0
+				e.TaskID = 0
+				e.ExternalReference = nil
+			}),
+			want: &TimeEntryUpdate{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffTimeEntry(&current, tt.desired)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffTimeEntry() = %+v, want %+v", got, tt.want)
+			}
+			if got.IsEmpty() != reflect.DeepEqual(tt.want, &TimeEntryUpdate{}) {
+				t.Errorf("IsEmpty() = %v for %+v", got.IsEmpty(), got)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package harvest
+
+import (
+	"strings"
+	"time"
+)
+
+// Company represents the Harvest account of the company
+type Company struct {
+	Name            string `json:"name"`
+	BaseURI         string `json:"base_uri"`
+	FullDomain      string `json:"full_domain"`
+	WeekStartDay    string `json:"week_start_day"`
+	ApprovalFeature bool   `json:"approval_feature"`
+
+	WantsTimestampTimers bool `json:"wants_timestamp_timers"` // Entries have start and end times instead of durations
+}
+
+// GetCompany retrieves the company of the authenticated account
+func (c *Client) GetCompany() (*Company, error) {
+	var company Company
+	if err := c.get("/company", nil, &company); err != nil {
+		return nil, err
+	}
+
+	return &company, nil
+}
+
+// WeekStart returns the first day of the week of the account, Monday if it is not set
+func (c *Company) WeekStart() time.Weekday {
+	switch strings.ToLower(c.WeekStartDay) {
+	case "sunday":
+		return time.Sunday
+	case "saturday":
+		return time.Saturday
+	default:
+		return time.Monday
+	}
+}
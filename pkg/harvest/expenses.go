@@ -0,0 +1,181 @@
+package harvest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Expense represents an expense in Harvest
+type Expense struct {
+	ID              int64           `json:"id"`
+	SpentDate       string          `json:"spent_date"`
+	Notes           string          `json:"notes,omitempty"`
+	TotalCost       float64         `json:"total_cost"`
+	Units           *float64        `json:"units,omitempty"`
+	Billable        bool            `json:"billable"`
+	IsLocked        bool            `json:"is_locked,omitempty"`
+	LockedReason    string          `json:"locked_reason,omitempty"`
+	ApprovalStatus  string          `json:"approval_status,omitempty"`
+	User            User            `json:"user,omitempty"`
+	Project         Project         `json:"project"`
+	ExpenseCategory ExpenseCategory `json:"expense_category"`
+	Receipt         *Receipt        `json:"receipt,omitempty"`
+}
+
+// ExpenseCategory represents an expense category in Harvest
+type ExpenseCategory struct {
+	ID        int64    `json:"id"`
+	Name      string   `json:"name"`
+	UnitName  string   `json:"unit_name,omitempty"`  // Set for unit based categories, e.g. "km"
+	UnitPrice *float64 `json:"unit_price,omitempty"` // Price per unit for unit based categories
+	IsActive  bool     `json:"is_active,omitempty"`
+}
+
+// IsUnitBased returns true if expenses in the category are entered as units instead of a total cost
+func (c *ExpenseCategory) IsUnitBased() bool {
+	return c.UnitName != "" && c.UnitPrice != nil
+}
+
+// Receipt represents the receipt attached to an expense
+type Receipt struct {
+	URL         string `json:"url"`
+	FileName    string `json:"file_name"`
+	FileSize    int64  `json:"file_size"`
+	ContentType string `json:"content_type"`
+}
+
+// ExpenseRequest represents the fields sent to create an expense
+type ExpenseRequest struct {
+	ProjectID         int64    `json:"project_id"`
+	ExpenseCategoryID int64    `json:"expense_category_id"`
+	SpentDate         string   `json:"spent_date"`
+	TotalCost         *float64 `json:"total_cost,omitempty"`
+	Units             *float64 `json:"units,omitempty"`
+	Notes             string   `json:"notes,omitempty"`
+	Billable          *bool    `json:"billable,omitempty"`
+}
+
+// ListExpenses retrieves all expenses matching the parameters, e.g. from and to
+func (c *Client) ListExpenses(params map[string]string) ([]Expense, error) {
+	return getAllPages[Expense](c, "/expenses", "expenses", params)
+}
+
+// GetExpense retrieves a specific expense by ID
+func (c *Client) GetExpense(id int64) (*Expense, error) {
+	var expense Expense
+	if err := c.get(fmt.Sprintf("/expenses/%d", id), nil, &expense); err != nil {
+		return nil, err
+	}
+
+	return &expense, nil
+}
+
+// ListExpenseCategories retrieves all expense categories matching the parameters, e.g. is_active
+func (c *Client) ListExpenseCategories(params map[string]string) ([]ExpenseCategory, error) {
+	return getAllPages[ExpenseCategory](c, "/expense_categories", "expense_categories", params)
+}
+
+// CreateExpense creates a new expense, uploading the receipt file if a path is given
+func (c *Client) CreateExpense(expense *ExpenseRequest, receiptPath string) (*Expense, error) {
+	requestURL := fmt.Sprintf("%s/expenses", c.baseURL)
+
+	var req *http.Request
+	if receiptPath == "" {
+		body, err := json.Marshal(expense)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal expense: %w", err)
+		}
+
+		req, err = http.NewRequest("POST", requestURL, bytes.NewBuffer(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		body, contentType, err := expenseMultipartBody(expense, receiptPath)
+		if err != nil {
+			return nil, err
+		}
+
+		req, err = http.NewRequest("POST", requestURL, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var created Expense
+	if err := c.do(req, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// DeleteExpense deletes an expense by ID
+func (c *Client) DeleteExpense(id int64) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/expenses/%d", c.baseURL, id), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	return c.do(req, nil)
+}
+
+// expenseMultipartBody encodes the expense fields and the receipt file as multipart form data
+func expenseMultipartBody(expense *ExpenseRequest, receiptPath string) (io.Reader, string, error) {
+	file, err := os.Open(receiptPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open receipt: %w", err)
+	}
+	defer file.Close()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	fields := map[string]string{
+		"project_id":          strconv.FormatInt(expense.ProjectID, 10),
+		"expense_category_id": strconv.FormatInt(expense.ExpenseCategoryID, 10),
+		"spent_date":          expense.SpentDate,
+	}
+	if expense.TotalCost != nil {
+		fields["total_cost"] = strconv.FormatFloat(*expense.TotalCost, 'f', -1, 64)
+	}
+	if expense.Units != nil {
+		fields["units"] = strconv.FormatFloat(*expense.Units, 'f', -1, 64)
+	}
+	if expense.Notes != "" {
+		fields["notes"] = expense.Notes
+	}
+	if expense.Billable != nil {
+		fields["billable"] = strconv.FormatBool(*expense.Billable)
+	}
+
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			return nil, "", fmt.Errorf("failed to write form field: %w", err)
+		}
+	}
+
+	part, err := writer.CreateFormFile("receipt", filepath.Base(receiptPath))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create receipt form file: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, "", fmt.Errorf("failed to read receipt: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to encode form data: %w", err)
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
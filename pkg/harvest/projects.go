@@ -0,0 +1,90 @@
+package harvest
+
+// ProjectDetails represents a project with its settings, as returned by the projects endpoint
+type ProjectDetails struct {
+	ID              int64         `json:"id"`
+	Name            string        `json:"name"`
+	Code            string        `json:"code,omitempty"`
+	IsActive        bool          `json:"is_active"`
+	IsBillable      bool          `json:"is_billable"`
+	IsFixedFee      bool          `json:"is_fixed_fee,omitempty"`
+	BillBy          string        `json:"bill_by,omitempty"`
+	Budget          *float64      `json:"budget,omitempty"`
+	BudgetBy        string        `json:"budget_by,omitempty"`
+	BudgetIsMonthly bool          `json:"budget_is_monthly,omitempty"`
+	StartsOn        string        `json:"starts_on,omitempty"`
+	EndsOn          string        `json:"ends_on,omitempty"`
+	Notes           string        `json:"notes,omitempty"`
+	Client          ClientDetails `json:"client"`
+}
+
+// IsHourBudget reports whether the budget is measured in hours rather than money
+func (p *ProjectDetails) IsHourBudget() bool {
+	switch p.BudgetBy {
+	case "project", "task", "person":
+		return true
+	default:
+		return false
+	}
+}
+
+// HasBudget reports whether a budget is set for the project
+func (p *ProjectDetails) HasBudget() bool {
+	return p.BudgetBy != "" && p.BudgetBy != "none" && p.Budget != nil && *p.Budget > 0
+}
+
+// ClientDetails represents a client in Harvest
+type ClientDetails struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	IsActive bool   `json:"is_active,omitempty"`
+	Currency string `json:"currency,omitempty"`
+}
+
+// TaskDetails represents a task with its settings, as returned by the tasks endpoint
+type TaskDetails struct {
+	ID                int64    `json:"id"`
+	Name              string   `json:"name"`
+	BillableByDefault bool     `json:"billable_by_default"`
+	DefaultHourlyRate *float64 `json:"default_hourly_rate,omitempty"`
+	IsDefault         bool     `json:"is_default"`
+	IsActive          bool     `json:"is_active"`
+}
+
+// TaskAssignment represents a task assigned to a project
+type TaskAssignment struct {
+	ID         int64            `json:"id"`
+	IsActive   bool             `json:"is_active"`
+	Billable   bool             `json:"billable"`
+	HourlyRate *float64         `json:"hourly_rate,omitempty"`
+	Budget     *float64         `json:"budget,omitempty"`
+	Project    ProjectReference `json:"project"`
+	Task       Task             `json:"task"`
+}
+
+// ProjectReference represents the project a task assignment belongs to
+type ProjectReference struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Code string `json:"code,omitempty"`
+}
+
+// ListProjects retrieves all projects matching the parameters, e.g. is_active or client_id
+func (c *Client) ListProjects(params map[string]string) ([]ProjectDetails, error) {
+	return getAllPages[ProjectDetails](c, "/projects", "projects", params)
+}
+
+// ListClients retrieves all clients matching the parameters, e.g. is_active
+func (c *Client) ListClients(params map[string]string) ([]ClientDetails, error) {
+	return getAllPages[ClientDetails](c, "/clients", "clients", params)
+}
+
+// ListTasks retrieves all tasks matching the parameters, e.g. is_active
+func (c *Client) ListTasks(params map[string]string) ([]TaskDetails, error) {
+	return getAllPages[TaskDetails](c, "/tasks", "tasks", params)
+}
+
+// ListTaskAssignments retrieves the task assignments of all projects matching the parameters, e.g. is_active
+func (c *Client) ListTaskAssignments(params map[string]string) ([]TaskAssignment, error) {
+	return getAllPages[TaskAssignment](c, "/task_assignments", "task_assignments", params)
+}
@@ -0,0 +1,118 @@
+package harvest
+
+import (
+	"time"
+)
+
+// TimeReportResult represents a row of the client, project, task or team time reports
+type TimeReportResult struct {
+	ClientID       int64   `json:"client_id,omitempty"`
+	ClientName     string  `json:"client_name,omitempty"`
+	ProjectID      int64   `json:"project_id,omitempty"`
+	ProjectName    string  `json:"project_name,omitempty"`
+	TaskID         int64   `json:"task_id,omitempty"`
+	TaskName       string  `json:"task_name,omitempty"`
+	UserID         int64   `json:"user_id,omitempty"`
+	UserName       string  `json:"user_name,omitempty"`
+	IsContractor   bool    `json:"is_contractor,omitempty"`
+	TotalHours     float64 `json:"total_hours"`
+	BillableHours  float64 `json:"billable_hours"`
+	Currency       string  `json:"currency"`
+	BillableAmount float64 `json:"billable_amount"`
+}
+
+// UninvoicedReportResult represents a row of the uninvoiced report
+type UninvoicedReportResult struct {
+	ClientID           int64   `json:"client_id"`
+	ClientName         string  `json:"client_name"`
+	ProjectID          int64   `json:"project_id"`
+	ProjectName        string  `json:"project_name"`
+	Currency           string  `json:"currency"`
+	TotalHours         float64 `json:"total_hours"`
+	UninvoicedHours    float64 `json:"uninvoiced_hours"`
+	UninvoicedExpenses float64 `json:"uninvoiced_expenses"`
+	UninvoicedAmount   float64 `json:"uninvoiced_amount"`
+}
+
+// ProjectBudget represents a row of the project budget report
+type ProjectBudget struct {
+	ProjectID       int64   `json:"project_id"`
+	ProjectName     string  `json:"project_name"`
+	ClientID        int64   `json:"client_id"`
+	ClientName      string  `json:"client_name"`
+	BudgetIsMonthly bool    `json:"budget_is_monthly"`
+	BudgetBy        string  `json:"budget_by"`
+	IsActive        bool    `json:"is_active"`
+	Budget          float64 `json:"budget"`
+	BudgetSpent     float64 `json:"budget_spent"`
+	BudgetRemaining float64 `json:"budget_remaining"`
+}
+
+// IsHourBudget reports whether the budget is measured in hours rather than money
+func (b *ProjectBudget) IsHourBudget() bool {
+	switch b.BudgetBy {
+	case "project", "task", "person":
+		return true
+	default:
+		return false
+	}
+}
+
+// GetProjectBudgetReport retrieves the budget report for all projects matching the parameters
+func (c *Client) GetProjectBudgetReport(params map[string]string) ([]ProjectBudget, error) {
+	return getAllResults[ProjectBudget](c, "/reports/project_budget", params)
+}
+
+// GetProjectBudget retrieves the budget report row of a single project, or nil if it has no budget
+func (c *Client) GetProjectBudget(projectID int64) (*ProjectBudget, error) {
+	budgets, err := c.GetProjectBudgetReport(map[string]string{"is_active": "true"})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, budget := range budgets {
+		if budget.ProjectID == projectID {
+			return &budgets[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetClientTimeReport retrieves the hours tracked per client between two dates
+func (c *Client) GetClientTimeReport(from, to time.Time) ([]TimeReportResult, error) {
+	return getAllResults[TimeReportResult](c, "/reports/time/clients", reportParams(from, to))
+}
+
+// GetProjectTimeReport retrieves the hours tracked per project between two dates
+func (c *Client) GetProjectTimeReport(from, to time.Time) ([]TimeReportResult, error) {
+	return getAllResults[TimeReportResult](c, "/reports/time/projects", reportParams(from, to))
+}
+
+// GetTaskTimeReport retrieves the hours tracked per task between two dates
+func (c *Client) GetTaskTimeReport(from, to time.Time) ([]TimeReportResult, error) {
+	return getAllResults[TimeReportResult](c, "/reports/time/tasks", reportParams(from, to))
+}
+
+// GetTeamTimeReport retrieves the hours tracked per user between two dates
+func (c *Client) GetTeamTimeReport(from, to time.Time) ([]TimeReportResult, error) {
+	return getAllResults[TimeReportResult](c, "/reports/time/team", reportParams(from, to))
+}
+
+// GetUninvoicedReport retrieves the uninvoiced hours and expenses per project between two dates
+func (c *Client) GetUninvoicedReport(from, to time.Time) ([]UninvoicedReportResult, error) {
+	return getAllResults[UninvoicedReportResult](c, "/reports/uninvoiced", reportParams(from, to))
+}
+
+// reportParams returns the date range parameters in the YYYYMMDD format expected by the reports API
+func reportParams(from, to time.Time) map[string]string {
+	return map[string]string{
+		"from": from.Format("20060102"),
+		"to":   to.Format("20060102"),
+	}
+}
+
+// getAllResults retrieves the results from every page of a report
+func getAllResults[T any](c *Client, path string, params map[string]string) ([]T, error) {
+	return getAllPages[T](c, path, "results", params)
+}
@@ -0,0 +1,16 @@
+package harvest
+
+// GetCurrentUser retrieves the user the access token belongs to
+func (c *Client) GetCurrentUser() (*User, error) {
+	var user User
+	if err := c.get("/users/me", nil, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// ListUsers retrieves all users matching the parameters, e.g. is_active
+func (c *Client) ListUsers(params map[string]string) ([]User, error) {
+	return getAllPages[User](c, "/users", "users", params)
+}
@@ -0,0 +1,288 @@
+package ics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Event represents a VEVENT component of an iCalendar file
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	Status       string // "TENTATIVE", "CONFIRMED" or "CANCELLED"
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	Categories   []string
+	Organizer    string // Email of the organizer
+	Attendees    []Attendee
+	Rule         string      // RRULE of a recurring event
+	ExDates      []time.Time // Start times of the occurrences excluded from the recurrence
+	RecurrenceID time.Time   // Start time of the occurrence of a recurring event this event replaces
+}
+
+// Attendee represents an ATTENDEE property of an event
+type Attendee struct {
+	Email  string
+	Name   string
+	Status string // PARTSTAT, e.g. "ACCEPTED", "DECLINED" or "NEEDS-ACTION"
+}
+
+// IsCancelled reports whether the event was cancelled
+func (e *Event) IsCancelled() bool {
+	return strings.EqualFold(e.Status, "CANCELLED")
+}
+
+// Attendee returns the attendee with the given email, or nil if they are not invited
+func (e *Event) Attendee(email string) *Attendee {
+	for i, attendee := range e.Attendees {
+		if strings.EqualFold(attendee.Email, email) {
+			return &e.Attendees[i]
+		}
+	}
+	return nil
+}
+
+// property represents a single content line of an iCalendar file
+type property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// ParseFile parses the iCalendar file at the given path
+func ParseFile(path string) ([]Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open calendar file: %w", err)
+	}
+	defer file.Close()
+
+	events, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse calendar file %s: %w", path, err)
+	}
+
+	return events, nil
+}
+
+// Parse reads all VEVENT components from an iCalendar stream
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	nested := 0 // Depth of components inside the current event, such as alarms
+
+	for _, line := range lines {
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case prop.Name == "BEGIN" && strings.EqualFold(prop.Value, "VEVENT"):
+			current = &Event{}
+		case current != nil && prop.Name == "BEGIN":
+			nested++
+		case current != nil && nested > 0:
+			if prop.Name == "END" {
+				nested--
+			}
+		case prop.Name == "END" && strings.EqualFold(prop.Value, "VEVENT"):
+			if current != nil {
+				// Events without an end last one day (all-day) or zero time
+				if current.End.IsZero() {
+					if current.AllDay {
+						current.End = current.Start.AddDate(0, 0, 1)
+					} else {
+						current.End = current.Start
+					}
+				}
+				events = append(events, *current)
+				current = nil
+			}
+		case current != nil:
+			if err := applyProperty(current, prop); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// unfoldLines joins folded content lines (lines starting with a space or tab)
+func unfoldLines(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	return lines, nil
+}
+
+// parseProperty splits a content line into name, parameters and value
+func parseProperty(line string) (property, error) {
+	// The value starts after the first colon that is not inside a quoted parameter
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon == -1 {
+		return property{}, fmt.Errorf("invalid content line: %s", line)
+	}
+
+	prop := property{
+		Params: make(map[string]string),
+		Value:  line[colon+1:],
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	prop.Name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		key, value, found := strings.Cut(param, "=")
+		if !found {
+			continue
+		}
+		prop.Params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+
+	return prop, nil
+}
+
+// applyProperty sets the event field corresponding to the property
+func applyProperty(event *Event, prop property) error {
+	switch prop.Name {
+	case "UID":
+		event.UID = prop.Value
+	case "SUMMARY":
+		event.Summary = unescapeText(prop.Value)
+	case "DESCRIPTION":
+		event.Description = unescapeText(prop.Value)
+	case "STATUS":
+		event.Status = strings.ToUpper(prop.Value)
+	case "ORGANIZER":
+		event.Organizer = calendarAddress(prop.Value)
+	case "ATTENDEE":
+		event.Attendees = append(event.Attendees, Attendee{
+			Email:  calendarAddress(prop.Value),
+			Name:   prop.Params["CN"],
+			Status: strings.ToUpper(prop.Params["PARTSTAT"]),
+		})
+	case "RRULE":
+		event.Rule = prop.Value
+	case "EXDATE":
+		for _, value := range strings.Split(prop.Value, ",") {
+			exDate, _, err := parseDateTime(property{Name: prop.Name, Params: prop.Params, Value: value})
+			if err != nil {
+				return err
+			}
+			event.ExDates = append(event.ExDates, exDate)
+		}
+	case "RECURRENCE-ID":
+		recurrenceID, _, err := parseDateTime(prop)
+		if err != nil {
+			return err
+		}
+		event.RecurrenceID = recurrenceID
+	case "CATEGORIES":
+		for _, category := range strings.Split(prop.Value, ",") {
+			event.Categories = append(event.Categories, unescapeText(strings.TrimSpace(category)))
+		}
+	case "DTSTART":
+		start, allDay, err := parseDateTime(prop)
+		if err != nil {
+			return err
+		}
+		event.Start = start
+		event.AllDay = allDay
+	case "DTEND":
+		end, _, err := parseDateTime(prop)
+		if err != nil {
+			return err
+		}
+		event.End = end
+	}
+
+	return nil
+}
+
+// parseDateTime parses DATE and DATE-TIME values, honouring the TZID parameter
+func parseDateTime(prop property) (time.Time, bool, error) {
+	value := prop.Value
+
+	if prop.Params["VALUE"] == "DATE" || len(value) == 8 {
+		date, err := time.ParseInLocation("20060102", value, time.Local)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s date: %s", prop.Name, value)
+		}
+		return date, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s date-time: %s", prop.Name, value)
+		}
+		return t.Local(), false, nil
+	}
+
+	location := time.Local
+	if tzid := prop.Params["TZID"]; tzid != "" {
+		if loc, err := time.LoadLocation(tzid); err == nil {
+			location = loc
+		}
+	}
+
+	t, err := time.ParseInLocation("20060102T150405", value, location)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s date-time: %s", prop.Name, value)
+	}
+
+	return t.Local(), false, nil
+}
+
+// calendarAddress returns the email of a CAL-ADDRESS value such as "mailto:me@example.com"
+func calendarAddress(value string) string {
+	if len(value) >= 7 && strings.EqualFold(value[:7], "mailto:") {
+		return value[7:]
+	}
+	return value
+}
+
+// unescapeText reverses the TEXT value escaping of RFC 5545
+func unescapeText(value string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(value)
+}
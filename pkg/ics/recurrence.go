@@ -0,0 +1,265 @@
+package ics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// recurrenceRule represents the supported parts of an RRULE
+type recurrenceRule struct {
+	Freq      string
+	Interval  int
+	Count     int
+	Until     time.Time
+	ByDay     []time.Weekday
+	WeekStart time.Weekday // First day of the week for weekly rules, Monday by default
+}
+
+// weekdays maps RRULE weekday codes to weekdays
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Occurrences returns the events that overlap a period, sorted by start time.
+// Recurring events are expanded into one event per occurrence (daily, weekly on
+// given weekdays, monthly and yearly recurrences are supported), without the
+// excluded dates and with modified occurrences replacing the original ones.
+// Recurring events with rules that aren't supported are left out and returned as errors.
+func Occurrences(events []Event, from, to time.Time) ([]Event, []error) {
+	// Modified occurrences replace the occurrence with the same recurrence ID
+	overridden := make(map[string]bool)
+	for _, event := range events {
+		if !event.RecurrenceID.IsZero() {
+			overridden[occurrenceKey(event.UID, event.RecurrenceID)] = true
+		}
+	}
+
+	var occurrences []Event
+	var unsupported []error
+	for _, event := range events {
+		if event.Rule == "" || !event.RecurrenceID.IsZero() {
+			if overlaps(event, from, to) {
+				occurrences = append(occurrences, event)
+			}
+			continue
+		}
+
+		rule, err := parseRule(event.Rule)
+		if err != nil {
+			unsupported = append(unsupported, fmt.Errorf("event %q: %w", event.Summary, err))
+			continue
+		}
+
+		duration := event.End.Sub(event.Start)
+		for _, start := range rule.starts(event.Start, to) {
+			if overridden[occurrenceKey(event.UID, start)] || isExcluded(event, start) {
+				continue
+			}
+
+			occurrence := event
+			occurrence.Start = start
+			occurrence.End = start.Add(duration)
+			if overlaps(occurrence, from, to) {
+				occurrences = append(occurrences, occurrence)
+			}
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Start.Before(occurrences[j].Start)
+	})
+
+	return occurrences, unsupported
+}
+
+// parseRule parses an RRULE value, rejecting the parts that aren't supported
+// rather than expanding the rule to wrong dates
+func parseRule(value string) (*recurrenceRule, error) {
+	rule := &recurrenceRule{Interval: 1, WeekStart: time.Monday}
+
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, found := strings.Cut(part, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid RRULE part: %s", part)
+		}
+
+		switch key = strings.ToUpper(key); key {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid RRULE interval: %s", val)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid RRULE count: %s", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, _, err := parseDateTime(property{Name: "UNTIL", Params: map[string]string{}, Value: val})
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				// Only plain weekdays are supported, not positions such as 2TU
+				weekday, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("unsupported RRULE weekday: %s", day)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "WKST":
+			weekday, ok := weekdays[strings.ToUpper(val)]
+			if !ok {
+				return nil, fmt.Errorf("invalid RRULE week start: %s", val)
+			}
+			rule.WeekStart = weekday
+		default:
+			// BYMONTHDAY, BYSETPOS, BYMONTH and the other BY* parts
+			return nil, fmt.Errorf("unsupported RRULE part: %s", key)
+		}
+	}
+
+	switch rule.Freq {
+	case "DAILY", "WEEKLY":
+		return rule, nil
+	case "MONTHLY", "YEARLY":
+		if len(rule.ByDay) > 0 {
+			return nil, fmt.Errorf("unsupported RRULE: BYDAY with FREQ=%s", rule.Freq)
+		}
+		return rule, nil
+	default:
+		return nil, fmt.Errorf("unsupported RRULE frequency: %s", rule.Freq)
+	}
+}
+
+// starts returns the start times of the occurrences of a rule that start before a time
+func (r *recurrenceRule) starts(first, before time.Time) []time.Time {
+	var starts []time.Time
+
+	// add records an occurrence and reports whether more may follow
+	add := func(start time.Time) bool {
+		if !start.Before(before) || (!r.Until.IsZero() && start.After(r.Until)) {
+			return false
+		}
+		if r.Count > 0 && len(starts) >= r.Count {
+			return false
+		}
+		starts = append(starts, start)
+		return true
+	}
+
+	for i := 0; ; i++ {
+		switch r.Freq {
+		case "DAILY":
+			start := first.AddDate(0, 0, i*r.Interval)
+			if !start.Before(before) {
+				return starts
+			}
+
+			// BYDAY limits daily rules to the given weekdays
+			if len(r.ByDay) > 0 && !containsWeekday(r.ByDay, start.Weekday()) {
+				continue
+			}
+			if !add(start) {
+				return starts
+			}
+		case "WEEKLY":
+			days := r.ByDay
+			if len(days) == 0 {
+				days = []time.Weekday{first.Weekday()}
+			}
+
+			offset := (int(first.Weekday()) - int(r.WeekStart) + 7) % 7
+			weekStart := first.AddDate(0, 0, 7*i*r.Interval-offset)
+			if !weekStart.Before(before) {
+				return starts
+			}
+
+			var weekStarts []time.Time
+			for _, day := range days {
+				weekStarts = append(weekStarts, weekStart.AddDate(0, 0, (int(day)-int(r.WeekStart)+7)%7))
+			}
+			sort.Slice(weekStarts, func(a, b int) bool {
+				return weekStarts[a].Before(weekStarts[b])
+			})
+
+			for _, start := range weekStarts {
+				if start.Before(first) {
+					continue
+				}
+				if !add(start) {
+					return starts
+				}
+			}
+		case "MONTHLY", "YEARLY":
+			start := first.AddDate(0, i*r.Interval, 0)
+			if r.Freq == "YEARLY" {
+				start = first.AddDate(i*r.Interval, 0, 0)
+			}
+			if !start.Before(before) {
+				return starts
+			}
+
+			// Months without the day of the first occurrence are skipped
+			if start.Day() != first.Day() {
+				continue
+			}
+			if !add(start) {
+				return starts
+			}
+		default:
+			return starts
+		}
+	}
+}
+
+// containsWeekday reports whether a weekday is in a list
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps reports whether an event overlaps a period, events without duration overlap if they start in it
+func overlaps(event Event, from, to time.Time) bool {
+	if event.End.Equal(event.Start) {
+		return !event.Start.Before(from) && event.Start.Before(to)
+	}
+	return event.Start.Before(to) && event.End.After(from)
+}
+
+// isExcluded reports whether an occurrence is excluded by an EXDATE
+func isExcluded(event Event, start time.Time) bool {
+	for _, exDate := range event.ExDates {
+		if exDate.Equal(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// occurrenceKey identifies an occurrence of a recurring event
+func occurrenceKey(uid string, start time.Time) string {
+	return fmt.Sprintf("%s/%d", uid, start.Unix())
+}
@@ -0,0 +1,208 @@
+package ics
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// date returns a local time for the tests
+func date(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.Local)
+}
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *recurrenceRule
+		wantErr string
+	}{
+		{
+			name:  "daily",
+			value: "FREQ=DAILY",
+			want:  &recurrenceRule{Freq: "DAILY", Interval: 1, WeekStart: time.Monday},
+		},
+		{
+			name:  "weekly with interval, count and weekdays",
+			value: "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=MO,we",
+			want: &recurrenceRule{Freq: "WEEKLY", Interval: 2, Count: 4,
+				ByDay: []time.Weekday{time.Monday, time.Wednesday}, WeekStart: time.Monday},
+		},
+		{
+			name:  "until and week start",
+			value: "FREQ=WEEKLY;UNTIL=20240331T000000;WKST=SU",
+			want: &recurrenceRule{Freq: "WEEKLY", Interval: 1, Until: date(2024, time.March, 31, 0),
+				WeekStart: time.Sunday},
+		},
+		{
+			name:  "monthly",
+			value: "FREQ=MONTHLY;INTERVAL=3",
+			want:  &recurrenceRule{Freq: "MONTHLY", Interval: 3, WeekStart: time.Monday},
+		},
+		{name: "weekday position", value: "FREQ=MONTHLY;BYDAY=2TU", wantErr: "unsupported RRULE weekday"},
+		{name: "weekdays of a month", value: "FREQ=MONTHLY;BYDAY=TU", wantErr: "BYDAY with FREQ=MONTHLY"},
+		{name: "day of month", value: "FREQ=MONTHLY;BYMONTHDAY=15", wantErr: "unsupported RRULE part: BYMONTHDAY"},
+		{name: "set position", value: "FREQ=MONTHLY;BYDAY=MO,TU;BYSETPOS=-1", wantErr: "unsupported RRULE"},
+		{name: "hourly", value: "FREQ=HOURLY", wantErr: "unsupported RRULE frequency"},
+		{name: "missing frequency", value: "COUNT=3", wantErr: "unsupported RRULE frequency"},
+		{name: "invalid interval", value: "FREQ=DAILY;INTERVAL=0", wantErr: "invalid RRULE interval"},
+		{name: "invalid count", value: "FREQ=DAILY;COUNT=x", wantErr: "invalid RRULE count"},
+		{name: "invalid week start", value: "FREQ=WEEKLY;WKST=XX", wantErr: "invalid RRULE week start"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRule(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseRule(%q) error = %v, want %q", tt.value, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRule(%q) error = %v", tt.value, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRule(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStarts(t *testing.T) {
+	// Monday 4 March 2024, 10:00
+	first := date(2024, time.March, 4, 10)
+
+	tests := []struct {
+		name   string
+		rule   string
+		first  time.Time
+		before time.Time
+		want   []time.Time
+	}{
+		{
+			name:   "daily with count",
+			rule:   "FREQ=DAILY;COUNT=3",
+			first:  first,
+			before: date(2024, time.April, 1, 0),
+			want:   []time.Time{first, date(2024, time.March, 5, 10), date(2024, time.March, 6, 10)},
+		},
+		{
+			name:   "daily until",
+			rule:   "FREQ=DAILY;INTERVAL=2;UNTIL=20240308T100000",
+			first:  first,
+			before: date(2024, time.April, 1, 0),
+			want:   []time.Time{first, date(2024, time.March, 6, 10), date(2024, time.March, 8, 10)},
+		},
+		{
+			name:   "daily on weekdays",
+			rule:   "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;COUNT=6",
+			first:  date(2024, time.March, 7, 10),
+			before: date(2024, time.April, 1, 0),
+			want: []time.Time{date(2024, time.March, 7, 10), date(2024, time.March, 8, 10),
+				date(2024, time.March, 11, 10), date(2024, time.March, 12, 10),
+				date(2024, time.March, 13, 10), date(2024, time.March, 14, 10)},
+		},
+		{
+			name:   "weekly on weekdays before the end",
+			rule:   "FREQ=WEEKLY;BYDAY=WE,MO",
+			first:  first,
+			before: date(2024, time.March, 13, 0),
+			want: []time.Time{first, date(2024, time.March, 6, 10),
+				date(2024, time.March, 11, 10)},
+		},
+		{
+			name:   "every other week with count",
+			rule:   "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			first:  first,
+			before: date(2024, time.May, 1, 0),
+			want: []time.Time{first, date(2024, time.March, 18, 10),
+				date(2024, time.April, 1, 10)},
+		},
+		{
+			name:   "every other week starting on Sunday",
+			rule:   "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,TU;WKST=SU;COUNT=4",
+			first:  date(2024, time.March, 5, 10), // Tuesday
+			before: date(2024, time.May, 1, 0),
+			want: []time.Time{date(2024, time.March, 5, 10), date(2024, time.March, 17, 10),
+				date(2024, time.March, 19, 10), date(2024, time.March, 31, 10)},
+		},
+		{
+			name:   "monthly skips months without the day",
+			rule:   "FREQ=MONTHLY;COUNT=3",
+			first:  date(2024, time.January, 31, 10),
+			before: date(2025, time.January, 1, 0),
+			want: []time.Time{date(2024, time.January, 31, 10), date(2024, time.March, 31, 10),
+				date(2024, time.May, 31, 10)},
+		},
+		{
+			name:   "yearly",
+			rule:   "FREQ=YEARLY;UNTIL=20260101T000000",
+			first:  first,
+			before: date(2030, time.January, 1, 0),
+			want:   []time.Time{first, date(2025, time.March, 4, 10)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRule(tt.rule)
+			if err != nil {
+				t.Fatalf("parseRule(%q) error = %v", tt.rule, err)
+			}
+			got := rule.starts(tt.first, tt.before)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("starts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	standup := Event{
+		UID:     "standup",
+		Summary: "Standup",
+		Start:   date(2024, time.March, 4, 9),
+		End:     date(2024, time.March, 4, 9).Add(15 * time.Minute),
+		Rule:    "FREQ=DAILY;COUNT=5",
+		ExDates: []time.Time{date(2024, time.March, 5, 9)},
+	}
+	moved := Event{
+		UID:          "standup",
+		Summary:      "Standup (moved)",
+		Start:        date(2024, time.March, 6, 11),
+		End:          date(2024, time.March, 6, 11).Add(15 * time.Minute),
+		RecurrenceID: date(2024, time.March, 6, 9),
+	}
+	monthly := Event{
+		UID:     "planning",
+		Summary: "Planning",
+		Start:   date(2024, time.March, 4, 14),
+		End:     date(2024, time.March, 4, 15),
+		Rule:    "FREQ=MONTHLY;BYDAY=2TU",
+	}
+
+	occurrences, unsupported := Occurrences([]Event{standup, moved, monthly},
+		date(2024, time.March, 4, 0), date(2024, time.March, 9, 0))
+
+	var got []string
+	for _, event := range occurrences {
+		got = append(got, event.Summary+" "+event.Start.Format("01-02 15:04"))
+	}
+	want := []string{
+		"Standup 03-04 09:00",
+		"Standup (moved) 03-06 11:00",
+		"Standup 03-07 09:00",
+		"Standup 03-08 09:00",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Occurrences() = %v, want %v", got, want)
+	}
+
+	// The event with an unsupported rule is reported instead of failing the whole calendar
+	if len(unsupported) != 1 || !strings.Contains(unsupported[0].Error(), "Planning") {
+		t.Errorf("Occurrences() unsupported = %v, want the Planning event", unsupported)
+	}
+}
@@ -0,0 +1,195 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"harvest-cli/pkg/harvest"
+)
+
+// Operation types
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// FileName is the name of the journal file in the data directory
+const FileName = "journal.jsonl"
+
+// Operation represents a mutating operation on a time entry
+type Operation struct {
+	ID        int64              `json:"id"`
+	Time      time.Time          `json:"time"`
+	AccountID string             `json:"account_id"`
+	Action    string             `json:"action"`
+	EntryID   int64              `json:"entry_id"`
+	Before    *harvest.TimeEntry `json:"before,omitempty"` // Snapshot before the operation, nil for creates
+	After     *harvest.TimeEntry `json:"after,omitempty"`  // Snapshot after the operation, nil for deletes
+	Undoes    int64              `json:"undoes,omitempty"` // ID of the operation reversed by this operation
+}
+
+// Journal is an append-only log of operations stored as JSON lines
+type Journal struct {
+	path   string
+	nextID int64 // ID of the next appended operation, 0 until the journal has been read
+}
+
+// Open returns the journal stored in the data directory
+func Open(dataDir string) *Journal {
+	return &Journal{path: filepath.Join(dataDir, FileName)}
+}
+
+// Path returns the path of the journal file
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Append assigns the next ID and timestamp to an operation and writes it to the journal
+func (j *Journal) Append(op *Operation) error {
+	if j.nextID == 0 {
+		lastID, err := j.lastID()
+		if err != nil {
+			return err
+		}
+		j.nextID = lastID + 1
+	}
+
+	op.ID = j.nextID
+	if op.Time.IsZero() {
+		op.Time = time.Now()
+	}
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("failed to encode operation: %w", err)
+	}
+
+	file, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	j.nextID++
+
+	return nil
+}
+
+// lastID returns the ID of the last operation in the journal, or 0 if it is empty.
+// Only the end of the file is read, so appending doesn't get slower as the journal grows.
+func (j *Journal) lastID() (int64, error) {
+	file, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	// Read larger chunks from the end until they contain the whole last line
+	size := info.Size()
+	for chunk := int64(4096); ; chunk *= 2 {
+		if chunk > size {
+			chunk = size
+		}
+		data := make([]byte, chunk)
+		if _, err := file.ReadAt(data, size-chunk); err != nil && err != io.EOF {
+			return 0, fmt.Errorf("failed to read journal: %w", err)
+		}
+
+		data = bytes.TrimRight(data, "\n")
+		start := bytes.LastIndexByte(data, '\n')
+		if start < 0 && chunk < size {
+			continue
+		}
+		line := data[start+1:]
+		if len(line) == 0 {
+			return 0, nil
+		}
+
+		var op Operation
+		if err := json.Unmarshal(line, &op); err != nil {
+			return 0, fmt.Errorf("failed to parse the last journal line: %w", err)
+		}
+		return op.ID, nil
+	}
+}
+
+// Operations returns all operations in the journal, oldest first
+func (j *Journal) Operations() ([]Operation, error) {
+	file, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+
+	var operations []Operation
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var op Operation
+		if err := json.Unmarshal(scanner.Bytes(), &op); err != nil {
+			return nil, fmt.Errorf("failed to parse journal line %d: %w", line, err)
+		}
+		operations = append(operations, op)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	return operations, nil
+}
+
+// LastUndoable returns the most recent operation of the account that hasn't been undone yet,
+// or nil if there is nothing to undo. Undo operations themselves are not undoable.
+func (j *Journal) LastUndoable(accountID string) (*Operation, error) {
+	operations, err := j.Operations()
+	if err != nil {
+		return nil, err
+	}
+
+	undone := UndoneIDs(operations)
+	for i := len(operations) - 1; i >= 0; i-- {
+		op := operations[i]
+		if op.AccountID != accountID || op.Undoes != 0 || undone[op.ID] {
+			continue
+		}
+		return &op, nil
+	}
+
+	return nil, nil
+}
+
+// UndoneIDs returns the IDs of the operations that have been reversed
+func UndoneIDs(operations []Operation) map[int64]bool {
+	undone := make(map[int64]bool)
+	for _, op := range operations {
+		if op.Undoes != 0 {
+			undone[op.Undoes] = true
+		}
+	}
+	return undone
+}
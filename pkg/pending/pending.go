@@ -0,0 +1,120 @@
+package pending
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"harvest-cli/pkg/gitlog"
+)
+
+// FileName is the name of the pending work file in the data directory
+const FileName = "pending.json"
+
+// Commit represents a commit recorded by a git hook that hasn't been logged yet
+type Commit struct {
+	RepoPath string `json:"repo_path"` // Root directory of the repository
+	gitlog.Commit
+}
+
+// Buffer holds the commits recorded by git hooks until they are logged as time entries
+type Buffer struct {
+	path    string
+	commits []Commit
+}
+
+// Load reads the buffer stored in the data directory
+func Load(dataDir string) (*Buffer, error) {
+	b := &Buffer{path: filepath.Join(dataDir, FileName)}
+
+	data, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pending work: %w", err)
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &b.commits); err != nil {
+			return nil, fmt.Errorf("failed to parse pending work: %w", err)
+		}
+	}
+
+	return b, nil
+}
+
+// Path returns the path of the buffer file
+func (b *Buffer) Path() string {
+	return b.path
+}
+
+// Commits returns the buffered commits, oldest first
+func (b *Buffer) Commits() []Commit {
+	return b.commits
+}
+
+// Add appends commits that aren't buffered yet and saves the buffer, returning the number of commits added
+func (b *Buffer) Add(commits ...Commit) (int, error) {
+	added := 0
+	for _, commit := range commits {
+		if b.contains(commit.Hash) {
+			continue
+		}
+		b.commits = append(b.commits, commit)
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+
+	sort.SliceStable(b.commits, func(i, j int) bool {
+		return b.commits[i].Time.Before(b.commits[j].Time)
+	})
+
+	return added, b.Save()
+}
+
+// Remove drops the commits with the given hashes and saves the buffer
+func (b *Buffer) Remove(hashes []string) error {
+	remove := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		remove[hash] = true
+	}
+
+	var commits []Commit
+	for _, commit := range b.commits {
+		if !remove[commit.Hash] {
+			commits = append(commits, commit)
+		}
+	}
+	b.commits = commits
+
+	return b.Save()
+}
+
+// Save writes the buffer to its file
+func (b *Buffer) Save() error {
+	data, err := json.MarshalIndent(b.commits, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode pending work: %w", err)
+	}
+
+	if err := os.WriteFile(b.path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write pending work: %w", err)
+	}
+
+	return nil
+}
+
+// contains reports whether a commit is buffered
+func (b *Buffer) contains(hash string) bool {
+	for _, commit := range b.commits {
+		if commit.Hash == hash {
+			return true
+		}
+	}
+	return false
+}
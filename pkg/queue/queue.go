@@ -0,0 +1,140 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"harvest-cli/pkg/harvest"
+)
+
+// Operation types
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// FileName is the name of the queue file in the data directory
+const FileName = "queue.json"
+
+// Item represents a time entry operation waiting to be sent to Harvest
+type Item struct {
+	ID        int64              `json:"id"`
+	Time      time.Time          `json:"time"`
+	AccountID string             `json:"account_id"`
+	Action    string             `json:"action"`
+	EntryID   int64              `json:"entry_id,omitempty"` // Entry to update or delete, 0 for creates
+	Request   *harvest.TimeEntry `json:"request,omitempty"`  // Fields to create or update
+	Snapshot  *harvest.TimeEntry `json:"snapshot,omitempty"` // Entry as last seen on the server, used to detect conflicts
+
+	Update *harvest.TimeEntryUpdate `json:"update,omitempty"` // Fields of an update queued without fetching the entry, sent as they are
+}
+
+// Queue holds the operations that couldn't be sent while Harvest was unreachable
+type Queue struct {
+	path   string
+	items  []Item
+	nextID int64 // Kept when the queue drains so IDs are never reused
+}
+
+// queueFile is the stored form of the queue
+type queueFile struct {
+	NextID int64  `json:"next_id"`
+	Items  []Item `json:"items"`
+}
+
+// Load reads the queue stored in the data directory
+func Load(dataDir string) (*Queue, error) {
+	q := &Queue{path: filepath.Join(dataDir, FileName)}
+
+	data, err := os.ReadFile(q.path)
+	if os.IsNotExist(err) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queue: %w", err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		// Queues written by earlier versions are a plain list of items
+		if err := json.Unmarshal(data, &q.items); err != nil {
+			return nil, fmt.Errorf("failed to parse queue: %w", err)
+		}
+	} else if len(data) > 0 {
+		var file queueFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse queue: %w", err)
+		}
+		q.items = file.Items
+		q.nextID = file.NextID
+	}
+
+	for _, item := range q.items {
+		if item.ID >= q.nextID {
+			q.nextID = item.ID + 1
+		}
+	}
+	if q.nextID < 1 {
+		q.nextID = 1
+	}
+
+	return q, nil
+}
+
+// Path returns the path of the queue file
+func (q *Queue) Path() string {
+	return q.path
+}
+
+// Items returns the queued operations of an account in the order they were added
+func (q *Queue) Items(accountID string) []Item {
+	var items []Item
+	for _, item := range q.items {
+		if item.AccountID == accountID {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Add appends an operation to the queue and saves it
+func (q *Queue) Add(item Item) (Item, error) {
+	item.ID = q.nextID
+	q.nextID++
+	if item.Time.IsZero() {
+		item.Time = time.Now()
+	}
+
+	q.items = append(q.items, item)
+	return item, q.Save()
+}
+
+// Remove deletes an operation from the queue and saves it
+func (q *Queue) Remove(id int64) error {
+	for i, item := range q.items {
+		if item.ID == id {
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			return q.Save()
+		}
+	}
+	return fmt.Errorf("queued operation %d not found", id)
+}
+
+// Save writes the queue to its file
+func (q *Queue) Save() error {
+	data, err := json.MarshalIndent(queueFile{NextID: q.nextID, Items: q.items}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode queue: %w", err)
+	}
+
+	if err := os.WriteFile(q.path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write queue: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package terminal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"github.com/chzyer/readline"
+)
+
+// KeyCode identifies a key read from the terminal
+type KeyCode int
+
+// Keys that can be read from the terminal
+const (
+	KeyRune KeyCode = iota // A printable character
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyTab
+	KeyEscape
+	KeyBackspace
+	KeyDelete
+	KeyCtrlC
+	KeyUnknown
+)
+
+// Key represents a key press
+type Key struct {
+	Code KeyCode
+	Rune rune // Character of a KeyRune
+}
+
+// ANSI escape sequences
+const (
+	AltScreenOn  = "\x1b[?1049h"
+	AltScreenOff = "\x1b[?1049l"
+	HideCursor   = "\x1b[?25l"
+	ShowCursor   = "\x1b[?25h"
+	ClearScreen  = "\x1b[H\x1b[2J"
+	Reverse      = "\x1b[7m"
+	Bold         = "\x1b[1m"
+	Dim          = "\x1b[2m"
+	Reset        = "\x1b[0m"
+)
+
+// Terminal is a full-screen terminal in raw mode
+type Terminal struct {
+	fd    int
+	state *readline.State
+	in    *bufio.Reader
+}
+
+// Open switches the terminal to raw mode and the alternate screen
+func Open() (*Terminal, error) {
+	fd := int(os.Stdin.Fd())
+	if !readline.IsTerminal(fd) || !readline.IsTerminal(int(os.Stdout.Fd())) {
+		return nil, errors.New("not an interactive terminal")
+	}
+
+	state, err := readline.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to switch the terminal to raw mode: %w", err)
+	}
+
+	fmt.Print(AltScreenOn + HideCursor)
+	return &Terminal{fd: fd, state: state, in: bufio.NewReader(os.Stdin)}, nil
+}
+
+// Close restores the screen and mode of the terminal
+func (t *Terminal) Close() error {
+	fmt.Print(ShowCursor + AltScreenOff)
+	return readline.Restore(t.fd, t.state)
+}
+
+// Size returns the width and height of the terminal, or 80x24 if unknown
+func (t *Terminal) Size() (int, int) {
+	width, height, err := readline.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// Draw replaces the screen with the given lines
+func (t *Terminal) Draw(lines []string) {
+	out := bufio.NewWriter(os.Stdout)
+	out.WriteString(ClearScreen)
+	for i, line := range lines {
+		if i > 0 {
+			out.WriteString("\r\n")
+		}
+		out.WriteString(line)
+	}
+	out.Flush()
+}
+
+// ReadKey waits for the next key press
+func (t *Terminal) ReadKey() (Key, error) {
+	r, _, err := t.in.ReadRune()
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch r {
+	case '\r', '\n':
+		return Key{Code: KeyEnter}, nil
+	case '\t':
+		return Key{Code: KeyTab}, nil
+	case 3:
+		return Key{Code: KeyCtrlC}, nil
+	case 8, 127:
+		return Key{Code: KeyBackspace}, nil
+	case 27:
+		return t.readEscape()
+	}
+
+	if r == utf8.RuneError || r < ' ' {
+		return Key{Code: KeyUnknown}, nil
+	}
+	return Key{Code: KeyRune, Rune: r}, nil
+}
+
+// readEscape reads the rest of an escape sequence, a lone escape is the Esc key
+func (t *Terminal) readEscape() (Key, error) {
+	// Sequences arrive at once, so nothing buffered means the Esc key was pressed
+	if t.in.Buffered() == 0 {
+		return Key{Code: KeyEscape}, nil
+	}
+
+	next, _ := t.in.ReadByte()
+	if next != '[' && next != 'O' {
+		return Key{Code: KeyEscape}, nil
+	}
+
+	// Read the parameters up to the final byte of the sequence
+	var params []byte
+	for {
+		b, err := t.in.ReadByte()
+		if err != nil {
+			return Key{}, err
+		}
+		if b >= 0x40 && b <= 0x7e {
+			return escapeKey(b, string(params)), nil
+		}
+		params = append(params, b)
+	}
+}
+
+// escapeKey returns the key of an escape sequence from its final byte and parameters
+func escapeKey(final byte, params string) Key {
+	switch final {
+	case 'A':
+		return Key{Code: KeyUp}
+	case 'B':
+		return Key{Code: KeyDown}
+	case 'C':
+		return Key{Code: KeyRight}
+	case 'D':
+		return Key{Code: KeyLeft}
+	case 'H':
+		return Key{Code: KeyHome}
+	case 'F':
+		return Key{Code: KeyEnd}
+	case '~':
+		switch params {
+		case "1", "7":
+			return Key{Code: KeyHome}
+		case "4", "8":
+			return Key{Code: KeyEnd}
+		case "3":
+			return Key{Code: KeyDelete}
+		}
+	}
+	return Key{Code: KeyUnknown}
+}